@@ -0,0 +1,217 @@
+package benefits
+
+import (
+	"errors"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+const (
+	// Age from which the lower CCB amount applies
+	ccbOlderAge = 6
+	// Age from which children are no longer eligible for child benefits
+	adultAge = 18
+)
+
+var (
+	ErrorUnsupportedYear = errors.New("no benefit data is available for this year")
+	ErrorInvalidAge      = errors.New("the age of a child cannot be negative")
+)
+
+// Canada Child Benefit parameters for a benefit year
+type ccbParameters struct {
+	underSix        types.Cash // Maximum yearly benefit per child under 6
+	older           types.Cash // Maximum yearly benefit per child aged 6 to 17
+	firstThreshold  types.Cash
+	secondThreshold types.Cash
+	firstRates      [4]types.Percentage // Reduction rates between the thresholds, for 1, 2, 3 and 4 or more children
+	secondRates     [4]types.Percentage // Reduction rates above the second threshold
+}
+
+// Ontario Child Benefit parameters for a benefit year
+type ontarioParameters struct {
+	perChild  types.Cash // Maximum yearly benefit per child
+	threshold types.Cash
+	rate      types.Percentage // Reduction rate above the threshold
+}
+
+// BC Family Benefit parameters for a benefit year
+type bcParameters struct {
+	maximums        [3]types.Cash // Maximum for the first, second and each additional child
+	minimums        [3]types.Cash // Benefit kept between the thresholds
+	firstThreshold  types.Cash
+	secondThreshold types.Cash
+	rate            types.Percentage // Reduction rate above each threshold
+}
+
+// Child benefit parameters, paid from July of the year after the income year
+type childParameters struct {
+	ccb     ccbParameters
+	ontario ontarioParameters
+	bc      bcParameters
+}
+
+var (
+	ccbFirstRates   = [4]types.Percentage{types.MustParsePercentage("7"), types.MustParsePercentage("13.5"), types.MustParsePercentage("19"), types.MustParsePercentage("23")}
+	ccbSecondRates  = [4]types.Percentage{types.MustParsePercentage("3.2"), types.MustParsePercentage("5.7"), types.MustParsePercentage("8"), types.MustParsePercentage("9.5")}
+	bcFamilyBenefit = bcParameters{
+		maximums:        [3]types.Cash{1750 * types.CashDollar, 1100 * types.CashDollar, 900 * types.CashDollar},
+		minimums:        [3]types.Cash{775 * types.CashDollar, 750 * types.CashDollar, 750 * types.CashDollar},
+		firstThreshold:  35902 * types.CashDollar,
+		secondThreshold: 114887 * types.CashDollar,
+		rate:            types.MustParsePercentage("4"),
+	}
+
+	// Parameters by income year
+	childYearParameters = map[types.Year]childParameters{
+		2023: {
+			ccb: ccbParameters{
+				underSix:        7787 * types.CashDollar,
+				older:           6570 * types.CashDollar,
+				firstThreshold:  36502 * types.CashDollar,
+				secondThreshold: 79087 * types.CashDollar,
+				firstRates:      ccbFirstRates,
+				secondRates:     ccbSecondRates,
+			},
+			ontario: ontarioParameters{
+				perChild:  1727 * types.CashDollar,
+				threshold: 26364 * types.CashDollar,
+				rate:      types.MustParsePercentage("8"),
+			},
+			bc: bcFamilyBenefit,
+		},
+		2024: {
+			ccb: ccbParameters{
+				underSix:        7997 * types.CashDollar,
+				older:           6748 * types.CashDollar,
+				firstThreshold:  37487 * types.CashDollar,
+				secondThreshold: 81222 * types.CashDollar,
+				firstRates:      ccbFirstRates,
+				secondRates:     ccbSecondRates,
+			},
+			// Indexed by 2.7% from the previous benefit year
+			ontario: ontarioParameters{
+				perChild:  1774 * types.CashDollar,
+				threshold: 27076 * types.CashDollar,
+				rate:      types.MustParsePercentage("8"),
+			},
+			bc: bcFamilyBenefit,
+		},
+	}
+	childFirstYear = types.Year(2023)
+	childLastYear  = types.Year(2024)
+)
+
+// Returns the child benefit parameters for an income year.
+// Years after the last one with known data reuse the latest parameters.
+func childParametersFor(year types.Year) (childParameters, error) {
+	if year < childFirstYear {
+		return childParameters{}, ErrorUnsupportedYear
+	}
+	if year > childLastYear {
+		year = childLastYear
+	}
+	return childYearParameters[year], nil
+}
+
+// Yearly child benefits of a family
+type ChildBenefits struct {
+	CCB        types.Cash // Canada Child Benefit
+	Provincial types.Cash // Ontario Child Benefit or BC Family Benefit
+}
+
+// Returns the total child benefits
+func (c ChildBenefits) Total() types.Cash {
+	return c.CCB + c.Provincial
+}
+
+// Computes the child benefits paid from July of the year after the income year,
+// given the ages of the children and the adjusted family net income.
+// Only the Ontario and British Columbia provincial benefits are modelled.
+func ChildBenefit(province types.Province, year types.Year, ages []int, familyIncome types.Cash) (ChildBenefits, error) {
+	params, err := childParametersFor(year)
+	if err != nil {
+		return ChildBenefits{}, err
+	}
+	benefits := ChildBenefits{}
+	children := 0
+	for _, age := range ages {
+		if age < 0 {
+			return ChildBenefits{}, ErrorInvalidAge
+		}
+		if age >= adultAge {
+			continue
+		}
+		children++
+		if age < ccbOlderAge {
+			benefits.CCB += params.ccb.underSix
+		} else {
+			benefits.CCB += params.ccb.older
+		}
+	}
+	if children == 0 {
+		return ChildBenefits{}, nil
+	}
+	if benefits.CCB, err = params.ccb.reduce(benefits.CCB, children, familyIncome); err != nil {
+		return ChildBenefits{}, err
+	}
+	switch province {
+	case types.Ontario:
+		benefits.Provincial, err = params.ontario.benefit(children, familyIncome)
+	case types.BritishColumbia:
+		benefits.Provincial, err = params.bc.benefit(children, familyIncome)
+	}
+	if err != nil {
+		return ChildBenefits{}, err
+	}
+	return benefits, nil
+}
+
+// Returns the CCB left after the income-tested reductions
+func (p ccbParameters) reduce(maximum types.Cash, children int, familyIncome types.Cash) (types.Cash, error) {
+	i := children - 1
+	if i >= len(p.firstRates) {
+		i = len(p.firstRates) - 1
+	}
+	first, err := types.MaxCash(0, types.MinCash(familyIncome, p.secondThreshold)-p.firstThreshold).Percentage(p.firstRates[i])
+	if err != nil {
+		return 0, err
+	}
+	second, err := types.MaxCash(0, familyIncome-p.secondThreshold).Percentage(p.secondRates[i])
+	if err != nil {
+		return 0, err
+	}
+	return types.MaxCash(0, maximum-first-second), nil
+}
+
+// Returns the Ontario Child Benefit
+func (p ontarioParameters) benefit(children int, familyIncome types.Cash) (types.Cash, error) {
+	reduction, err := types.MaxCash(0, familyIncome-p.threshold).Percentage(p.rate)
+	if err != nil {
+		return 0, err
+	}
+	return types.MaxCash(0, p.perChild*types.Cash(children)-reduction), nil
+}
+
+// Returns the BC Family Benefit, reduced down to a minimum above the first
+// threshold, then down to nothing above the second
+func (p bcParameters) benefit(children int, familyIncome types.Cash) (types.Cash, error) {
+	maximum, minimum := types.Cash(0), types.Cash(0)
+	for i := 0; i < children; i++ {
+		j := i
+		if j >= len(p.maximums) {
+			j = len(p.maximums) - 1
+		}
+		maximum += p.maximums[j]
+		minimum += p.minimums[j]
+	}
+	first, err := types.MaxCash(0, familyIncome-p.firstThreshold).Percentage(p.rate)
+	if err != nil {
+		return 0, err
+	}
+	second, err := types.MaxCash(0, familyIncome-p.secondThreshold).Percentage(p.rate)
+	if err != nil {
+		return 0, err
+	}
+	return types.MaxCash(0, types.MaxCash(minimum, maximum-first)-second), nil
+}
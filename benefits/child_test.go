@@ -0,0 +1,56 @@
+package benefits_test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/benefits"
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+func TestChildBenefit(t *testing.T) {
+	testCases := map[struct {
+		province types.Province
+		ages     string
+		income   types.Cash
+	}]benefits.ChildBenefits{
+		{types.Ontario, "3", 30000 * types.CashDollar}:               {7787 * types.CashDollar, 14361200},
+		{types.Alberta, "3 8", 60000 * types.CashDollar}:             {111847700, 0},
+		{types.Alberta, "10", 100000 * types.CashDollar}:             {29198340, 0},
+		{types.Alberta, "18", 10000 * types.CashDollar}:              {0, 0},
+		{types.Alberta, "10 10 10 10 10", 200000 * types.CashDollar}: {115687150, 0},
+		{types.BritishColumbia, "10 12", 50000 * types.CashDollar}:   {113177700, 22860800},
+		{types.BritishColumbia, "10 12", 120000 * types.CashDollar}:  {50589840, 13204800},
+		{types.BritishColumbia, "10 12", 200000 * types.CashDollar}:  {4989840, 0},
+	}
+
+	for testParams, testExpected := range testCases {
+		ages := []int{}
+		for _, field := range strings.Fields(testParams.ages) {
+			age, err := strconv.Atoi(field)
+			if err != nil {
+				t.Fatalf("Invalid age %q: %v", field, err)
+			}
+			ages = append(ages, age)
+		}
+		result, err := benefits.ChildBenefit(testParams.province, 2023, ages, testParams.income)
+		if err != nil {
+			t.Fatalf("Unexpected error for %v: %v", testParams, err)
+		}
+		if result != testExpected {
+			t.Fatalf("Expected %d and %d for %v, got %d and %d",
+				testExpected.CCB, testExpected.Provincial, testParams, result.CCB, result.Provincial)
+		}
+		if result.Total() != result.CCB+result.Provincial {
+			t.Fatalf("Unexpected total %s for %v", result.Total(), testParams)
+		}
+	}
+
+	if _, err := benefits.ChildBenefit(types.Ontario, 2022, []int{1}, 0); err != benefits.ErrorUnsupportedYear {
+		t.Fatalf("Expected %v, got %v", benefits.ErrorUnsupportedYear, err)
+	}
+	if _, err := benefits.ChildBenefit(types.Ontario, 2024, []int{-1}, 0); err != benefits.ErrorInvalidAge {
+		t.Fatalf("Expected %v, got %v", benefits.ErrorInvalidAge, err)
+	}
+}
@@ -0,0 +1,218 @@
+package benefits
+
+import (
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+const (
+	// Age from which dependants no longer count for the sales tax credits
+	creditAdultAge = 19
+)
+
+// GST/HST credit parameters for a benefit year
+type gstParameters struct {
+	individual          types.Cash // Credit for the individual, and for a spouse or the first child of a single parent
+	child               types.Cash // Credit for each child
+	supplement          types.Cash // Maximum supplement for single individuals
+	supplementThreshold types.Cash
+	supplementRate      types.Percentage
+	threshold           types.Cash // Family net income above which the credit is reduced
+	rate                types.Percentage
+}
+
+// Ontario sales tax credit parameters for a benefit year
+type ontarioCreditParameters struct {
+	perPerson       types.Cash // Credit for the individual, a spouse and each child
+	singleThreshold types.Cash // Threshold for individuals without a spouse or children
+	familyThreshold types.Cash
+	rate            types.Percentage
+}
+
+// BC climate action tax credit parameters for a benefit year
+type bcCreditParameters struct {
+	individual      types.Cash
+	spouse          types.Cash // Credit for a spouse, or the first child of a single parent
+	child           types.Cash // Credit for each other child
+	singleThreshold types.Cash // Threshold for individuals without a spouse or children
+	familyThreshold types.Cash
+	rate            types.Percentage
+}
+
+// Sales tax credit parameters, paid from July of the year after the income year
+type creditParameters struct {
+	gst     gstParameters
+	ontario ontarioCreditParameters
+	bc      bcCreditParameters
+}
+
+var (
+	// Parameters by income year
+	creditYearParameters = map[types.Year]creditParameters{
+		2023: {
+			gst: gstParameters{
+				individual:          340 * types.CashDollar,
+				child:               179 * types.CashDollar,
+				supplement:          179 * types.CashDollar,
+				supplementThreshold: 11039 * types.CashDollar,
+				supplementRate:      types.MustParsePercentage("2"),
+				threshold:           44324 * types.CashDollar,
+				rate:                types.MustParsePercentage("5"),
+			},
+			ontario: ontarioCreditParameters{
+				perPerson:       360 * types.CashDollar,
+				singleThreshold: 27729 * types.CashDollar,
+				familyThreshold: 34661 * types.CashDollar,
+				rate:            types.MustParsePercentage("4"),
+			},
+			bc: bcCreditParameters{
+				individual:      504 * types.CashDollar,
+				spouse:          252 * types.CashDollar,
+				child:           126 * types.CashDollar,
+				singleThreshold: 39115 * types.CashDollar,
+				familyThreshold: 54762 * types.CashDollar,
+				rate:            types.MustParsePercentage("2"),
+			},
+		},
+		2024: {
+			gst: gstParameters{
+				individual:          349 * types.CashDollar,
+				child:               184 * types.CashDollar,
+				supplement:          184 * types.CashDollar,
+				supplementThreshold: 11337 * types.CashDollar,
+				supplementRate:      types.MustParsePercentage("2"),
+				threshold:           45521 * types.CashDollar,
+				rate:                types.MustParsePercentage("5"),
+			},
+			ontario: ontarioCreditParameters{
+				perPerson:       371 * types.CashDollar,
+				singleThreshold: 29047 * types.CashDollar,
+				familyThreshold: 36309 * types.CashDollar,
+				rate:            types.MustParsePercentage("4"),
+			},
+			// The BC climate action tax credit ended in April 2025
+			bc: bcCreditParameters{},
+		},
+	}
+	creditFirstYear = types.Year(2023)
+	creditLastYear  = types.Year(2024)
+)
+
+// Returns the sales tax credit parameters for an income year.
+// Years after the last one with known data reuse the latest parameters.
+func creditParametersFor(year types.Year) (creditParameters, error) {
+	if year < creditFirstYear {
+		return creditParameters{}, ErrorUnsupportedYear
+	}
+	if year > creditLastYear {
+		year = creditLastYear
+	}
+	return creditYearParameters[year], nil
+}
+
+// Yearly sales tax credits of a family
+type Credits struct {
+	GSTHST             types.Cash // GST/HST credit
+	ProvincialSalesTax types.Cash // Ontario sales tax credit or BC climate action tax credit
+}
+
+// Returns the total credits
+func (c Credits) Total() types.Cash {
+	return c.GSTHST + c.ProvincialSalesTax
+}
+
+// Computes the sales tax credits paid from July of the year after the income year,
+// given the marital status, the ages of the children and the family net income.
+// Only the Ontario and British Columbia provincial credits are modelled.
+// Of the Ontario Trillium Benefit only the sales tax credit is included, the energy and
+// property tax credit and the Northern Ontario energy credit depend on housing costs
+// and are not modelled.
+func SalesTaxCredits(province types.Province, year types.Year, married bool, ages []int, familyIncome types.Cash) (Credits, error) {
+	params, err := creditParametersFor(year)
+	if err != nil {
+		return Credits{}, err
+	}
+	children := 0
+	for _, age := range ages {
+		if age < 0 {
+			return Credits{}, ErrorInvalidAge
+		}
+		if age < creditAdultAge {
+			children++
+		}
+	}
+	credits := Credits{}
+	if credits.GSTHST, err = params.gst.credit(married, children, familyIncome); err != nil {
+		return Credits{}, err
+	}
+	switch province {
+	case types.Ontario:
+		credits.ProvincialSalesTax, err = params.ontario.credit(married, children, familyIncome)
+	case types.BritishColumbia:
+		credits.ProvincialSalesTax, err = params.bc.credit(married, children, familyIncome)
+	}
+	if err != nil {
+		return Credits{}, err
+	}
+	return credits, nil
+}
+
+// Returns the GST/HST credit, single parents claim their first child like a spouse
+func (p gstParameters) credit(married bool, children int, familyIncome types.Cash) (types.Cash, error) {
+	credit := p.individual + p.child*types.Cash(children)
+	if married || children > 0 {
+		credit += p.individual - p.child*types.Cash(boolToInt(!married))
+	}
+	if !married {
+		supplement := p.supplement
+		if children == 0 {
+			phaseIn, err := types.MaxCash(0, familyIncome-p.supplementThreshold).Percentage(p.supplementRate)
+			if err != nil {
+				return 0, err
+			}
+			supplement = types.MinCash(supplement, phaseIn)
+		}
+		credit += supplement
+	}
+	reduction, err := types.MaxCash(0, familyIncome-p.threshold).Percentage(p.rate)
+	if err != nil {
+		return 0, err
+	}
+	return types.MaxCash(0, credit-reduction), nil
+}
+
+// Returns the Ontario sales tax credit
+func (p ontarioCreditParameters) credit(married bool, children int, familyIncome types.Cash) (types.Cash, error) {
+	people := 1 + boolToInt(married) + children
+	threshold := p.familyThreshold
+	if people == 1 {
+		threshold = p.singleThreshold
+	}
+	reduction, err := types.MaxCash(0, familyIncome-threshold).Percentage(p.rate)
+	if err != nil {
+		return 0, err
+	}
+	return types.MaxCash(0, p.perPerson*types.Cash(people)-reduction), nil
+}
+
+// Returns the BC climate action tax credit, single parents claim their first child like a spouse
+func (p bcCreditParameters) credit(married bool, children int, familyIncome types.Cash) (types.Cash, error) {
+	credit := p.individual + p.child*types.Cash(children)
+	threshold := p.singleThreshold
+	if married || children > 0 {
+		credit += p.spouse - p.child*types.Cash(boolToInt(!married))
+		threshold = p.familyThreshold
+	}
+	reduction, err := types.MaxCash(0, familyIncome-threshold).Percentage(p.rate)
+	if err != nil {
+		return 0, err
+	}
+	return types.MaxCash(0, credit-reduction), nil
+}
+
+// Returns 1 if b is true, 0 otherwise
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
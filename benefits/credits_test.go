@@ -0,0 +1,57 @@
+package benefits_test
+
+import (
+	"testing"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/benefits"
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+func TestSalesTaxCredits(t *testing.T) {
+	testCases := map[struct {
+		province types.Province
+		year     types.Year
+		married  bool
+		children int
+		income   types.Cash
+	}]benefits.Credits{
+		{types.Ontario, 2023, false, 0, 5000 * types.CashDollar}:          {340 * types.CashDollar, 360 * types.CashDollar},
+		{types.Ontario, 2023, false, 0, 20000 * types.CashDollar}:         {519 * types.CashDollar, 360 * types.CashDollar},
+		{types.Ontario, 2023, false, 0, 50000 * types.CashDollar}:         {23520 * types.CashCent, 0},
+		{types.Ontario, 2023, true, 2, 30000 * types.CashDollar}:          {1038 * types.CashDollar, 1440 * types.CashDollar},
+		{types.Ontario, 2023, false, 1, 30000 * types.CashDollar}:         {859 * types.CashDollar, 720 * types.CashDollar},
+		{types.BritishColumbia, 2023, false, 0, 20000 * types.CashDollar}: {519 * types.CashDollar, 504 * types.CashDollar},
+		{types.BritishColumbia, 2023, true, 2, 60000 * types.CashDollar}:  {25420 * types.CashCent, 90324 * types.CashCent},
+		{types.BritishColumbia, 2024, false, 0, 20000 * types.CashDollar}: {52226 * types.CashCent, 0},
+		{types.Alberta, 2023, false, 0, 100000 * types.CashDollar}:        {0, 0},
+	}
+
+	for testParams, testExpected := range testCases {
+		ages := make([]int, testParams.children)
+		credits, err := benefits.SalesTaxCredits(testParams.province, testParams.year, testParams.married, ages, testParams.income)
+		if err != nil {
+			t.Fatalf("Unexpected error for %v: %v", testParams, err)
+		}
+		if credits != testExpected {
+			t.Fatalf("Expected %+v for %v, got %+v", testExpected, testParams, credits)
+		}
+		if credits.Total() != credits.GSTHST+credits.ProvincialSalesTax {
+			t.Fatalf("Unexpected total %s for %v", credits.Total(), testParams)
+		}
+	}
+
+	// Adult children don't count
+	adult, err := benefits.SalesTaxCredits(types.Ontario, 2023, true, []int{19}, 30000*types.CashDollar)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if adult.GSTHST != 680*types.CashDollar {
+		t.Fatalf("Expected $680, got %s", adult.GSTHST)
+	}
+	if _, err := benefits.SalesTaxCredits(types.Ontario, 2022, false, nil, 0); err != benefits.ErrorUnsupportedYear {
+		t.Fatalf("Expected %v, got %v", benefits.ErrorUnsupportedYear, err)
+	}
+	if _, err := benefits.SalesTaxCredits(types.Ontario, 2023, false, []int{-2}, 0); err != benefits.ErrorInvalidAge {
+		t.Fatalf("Expected %v, got %v", benefits.ErrorInvalidAge, err)
+	}
+}
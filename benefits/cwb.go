@@ -0,0 +1,139 @@
+package benefits
+
+import (
+	"errors"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+var (
+	ErrorInvalidDisabled = errors.New("the number of disabled members must be between 0 and the number of adults")
+)
+
+// Canada Workers Benefit parameters for a year
+type cwbParameters struct {
+	phaseInThreshold    types.Cash // Working income above which the benefit is phased in
+	phaseInRate         types.Percentage
+	singleMax           types.Cash
+	familyMax           types.Cash
+	singleThreshold     types.Cash // Adjusted net income above which the benefit is reduced
+	familyThreshold     types.Cash
+	rate                types.Percentage
+	disabilityThreshold types.Cash // Working income above which the disability supplement is phased in
+	disabilityMax       types.Cash
+	disabilitySingle    types.Cash // Adjusted net income above which the supplement is reduced
+	disabilityFamily    types.Cash
+	disabilityRate      types.Percentage
+	disabilityBothRate  types.Percentage // Reduction rate when both spouses are eligible
+}
+
+var (
+	// Parameters by income year
+	cwbYearParameters = map[types.Year]cwbParameters{
+		2023: {
+			phaseInThreshold:    3000 * types.CashDollar,
+			phaseInRate:         types.MustParsePercentage("27"),
+			singleMax:           1518 * types.CashDollar,
+			familyMax:           2616 * types.CashDollar,
+			singleThreshold:     23495 * types.CashDollar,
+			familyThreshold:     26805 * types.CashDollar,
+			rate:                types.MustParsePercentage("15"),
+			disabilityThreshold: 1150 * types.CashDollar,
+			disabilityMax:       784 * types.CashDollar,
+			disabilitySingle:    34673 * types.CashDollar,
+			disabilityFamily:    45785 * types.CashDollar,
+			disabilityRate:      types.MustParsePercentage("15"),
+			disabilityBothRate:  types.MustParsePercentage("7.5"),
+		},
+		2024: {
+			phaseInThreshold:    3000 * types.CashDollar,
+			phaseInRate:         types.MustParsePercentage("27"),
+			singleMax:           1590 * types.CashDollar,
+			familyMax:           2739 * types.CashDollar,
+			singleThreshold:     24975 * types.CashDollar,
+			familyThreshold:     28494 * types.CashDollar,
+			rate:                types.MustParsePercentage("15"),
+			disabilityThreshold: 1150 * types.CashDollar,
+			disabilityMax:       821 * types.CashDollar,
+			disabilitySingle:    36748 * types.CashDollar,
+			disabilityFamily:    48091 * types.CashDollar,
+			disabilityRate:      types.MustParsePercentage("15"),
+			disabilityBothRate:  types.MustParsePercentage("7.5"),
+		},
+	}
+	cwbFirstYear = types.Year(2023)
+	cwbLastYear  = types.Year(2024)
+)
+
+// Returns the Canada Workers Benefit parameters for a year.
+// Years after the last one with known data reuse the latest parameters.
+func cwbParametersFor(year types.Year) (cwbParameters, error) {
+	if year < cwbFirstYear {
+		return cwbParameters{}, ErrorUnsupportedYear
+	}
+	if year > cwbLastYear {
+		year = cwbLastYear
+	}
+	return cwbYearParameters[year], nil
+}
+
+// The Canada Workers Benefit of a family for a year
+type WorkersBenefit struct {
+	Basic      types.Cash
+	Disability types.Cash // Disability supplement
+}
+
+// Returns the total benefit
+func (w WorkersBenefit) Total() types.Cash {
+	return w.Basic + w.Disability
+}
+
+// Computes the Canada Workers Benefit given the working income and adjusted net income of the family,
+// and the number of members eligible for the disability tax credit.
+// Families are individuals with a spouse or an eligible dependant, such as a child under 19.
+// The Quebec, Alberta and Nunavut specific configurations are not modelled.
+func CWB(year types.Year, family bool, workingIncome types.Cash, familyIncome types.Cash, disabled int) (WorkersBenefit, error) {
+	if disabled < 0 || (!family && disabled > 1) || disabled > 2 {
+		return WorkersBenefit{}, ErrorInvalidDisabled
+	}
+	params, err := cwbParametersFor(year)
+	if err != nil {
+		return WorkersBenefit{}, err
+	}
+	maximum, threshold := params.singleMax, params.singleThreshold
+	disabilityThreshold, disabilityRate := params.disabilitySingle, params.disabilityRate
+	if family {
+		maximum, threshold = params.familyMax, params.familyThreshold
+		disabilityThreshold = params.disabilityFamily
+		if disabled > 1 {
+			disabilityRate = params.disabilityBothRate
+		}
+	}
+	benefit := WorkersBenefit{}
+	if benefit.Basic, err = phased(workingIncome-params.phaseInThreshold, params.phaseInRate, maximum,
+		familyIncome-threshold, params.rate); err != nil {
+		return WorkersBenefit{}, err
+	}
+	for i := 0; i < disabled; i++ {
+		supplement, err := phased(workingIncome-params.disabilityThreshold, params.phaseInRate, params.disabilityMax,
+			familyIncome-disabilityThreshold, disabilityRate)
+		if err != nil {
+			return WorkersBenefit{}, err
+		}
+		benefit.Disability += supplement
+	}
+	return benefit, nil
+}
+
+// Returns a benefit phased in at a rate up to a maximum, then reduced at a rate
+func phased(phaseIn types.Cash, phaseInRate types.Percentage, maximum types.Cash, excess types.Cash, rate types.Percentage) (types.Cash, error) {
+	earned, err := types.MaxCash(0, phaseIn).Percentage(phaseInRate)
+	if err != nil {
+		return 0, err
+	}
+	reduction, err := types.MaxCash(0, excess).Percentage(rate)
+	if err != nil {
+		return 0, err
+	}
+	return types.MaxCash(0, types.MinCash(earned, maximum)-reduction), nil
+}
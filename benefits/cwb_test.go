@@ -0,0 +1,51 @@
+package benefits_test
+
+import (
+	"testing"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/benefits"
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+func TestCWB(t *testing.T) {
+	testCases := map[struct {
+		family   bool
+		working  types.Cash
+		income   types.Cash
+		disabled int
+	}]benefits.WorkersBenefit{
+		{false, 0, 0, 0}: {0, 0},
+		{false, 5000 * types.CashDollar, 5000 * types.CashDollar, 0}:   {540 * types.CashDollar, 0},
+		{false, 10000 * types.CashDollar, 10000 * types.CashDollar, 0}: {1590 * types.CashDollar, 0},
+		{false, 30000 * types.CashDollar, 30000 * types.CashDollar, 0}: {83625 * types.CashCent, 0},
+		{true, 30000 * types.CashDollar, 30000 * types.CashDollar, 0}:  {251310 * types.CashCent, 0},
+		{false, 10000 * types.CashDollar, 10000 * types.CashDollar, 1}: {1590 * types.CashDollar, 821 * types.CashDollar},
+		{true, 40000 * types.CashDollar, 50000 * types.CashDollar, 2}:  {0, 135565 * types.CashCent},
+		{false, 80000 * types.CashDollar, 80000 * types.CashDollar, 1}: {0, 0},
+	}
+
+	for testParams, testExpected := range testCases {
+		benefit, err := benefits.CWB(2024, testParams.family, testParams.working, testParams.income, testParams.disabled)
+		if err != nil {
+			t.Fatalf("Unexpected error for %v: %v", testParams, err)
+		}
+		if benefit != testExpected {
+			t.Fatalf("Expected %+v for %v, got %+v", testExpected, testParams, benefit)
+		}
+		if benefit.Total() != benefit.Basic+benefit.Disability {
+			t.Fatalf("Unexpected total %s for %v", benefit.Total(), testParams)
+		}
+	}
+
+	for _, invalid := range []struct {
+		family   bool
+		disabled int
+	}{{false, -1}, {false, 2}, {true, 3}} {
+		if _, err := benefits.CWB(2024, invalid.family, 0, 0, invalid.disabled); err != benefits.ErrorInvalidDisabled {
+			t.Fatalf("Expected %v for %+v, got %v", benefits.ErrorInvalidDisabled, invalid, err)
+		}
+	}
+	if _, err := benefits.CWB(2022, false, 0, 0, 0); err != benefits.ErrorUnsupportedYear {
+		t.Fatalf("Expected %v, got %v", benefits.ErrorUnsupportedYear, err)
+	}
+}
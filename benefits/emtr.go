@@ -0,0 +1,86 @@
+package benefits
+
+import (
+	"errors"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/tax"
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+var (
+	ErrorInvalidRange = errors.New("the range of incomes must be increasing with a positive step")
+)
+
+// The effective marginal rate of a household at an employment income
+type MarginalPoint struct {
+	Employment types.Cash
+	Rate       types.Percentage // Share of the next step lost to taxes, contributions and benefit reductions
+	TaxRate    types.Percentage // Share of the next step lost to taxes and contributions only
+	PhaseOuts  []string         // Benefits and credits reduced over the next step
+}
+
+// Returns true if multiple benefits are reduced at the same time, stacking their phase-out rates
+func (p MarginalPoint) Overlapping() bool {
+	return len(p.PhaseOuts) > 1
+}
+
+// Returns the effective marginal rates as the employment income of a parent goes from one amount
+// to another. Each point covers the increase to the next step, listing the benefits phasing out.
+func (h Household) MarginalRates(year types.Year, parent int, from types.Cash, to types.Cash, step types.Cash) ([]MarginalPoint, error) {
+	if parent < 0 || parent >= len(h.Parents) {
+		return nil, ErrorUnknownParent
+	}
+	if step <= 0 || from < 0 || to < from {
+		return nil, ErrorInvalidRange
+	}
+	parents := make([]tax.Income, len(h.Parents))
+	copy(parents, h.Parents)
+	at := h
+	at.Parents = parents
+	calculate := func(employment types.Cash) (HouseholdResult, error) {
+		parents[parent].Employment = employment
+		return at.Calculate(year)
+	}
+	current, err := calculate(from)
+	if err != nil {
+		return nil, err
+	}
+	points := []MarginalPoint{}
+	for employment := from; employment < to; employment += step {
+		next, err := calculate(employment + step)
+		if err != nil {
+			return nil, err
+		}
+		point := MarginalPoint{Employment: employment, PhaseOuts: phaseOuts(current, next)}
+		if point.Rate, err = (step - next.Disposable() + current.Disposable()).FractionOf(step); err != nil {
+			return nil, err
+		}
+		if point.TaxRate, err = (step - next.netPay() + current.netPay()).FractionOf(step); err != nil {
+			return nil, err
+		}
+		points = append(points, point)
+		current = next
+	}
+	return points, nil
+}
+
+// Returns the names of the benefits and credits reduced between two results
+func phaseOuts(current HouseholdResult, next HouseholdResult) []string {
+	names := []string{}
+	for _, benefit := range []struct {
+		name          string
+		current, next types.Cash
+	}{
+		{"CCB", current.Benefits.CCB, next.Benefits.CCB},
+		{"Provincial child benefit", current.Benefits.Provincial, next.Benefits.Provincial},
+		{"GST/HST credit", current.Credits.GSTHST, next.Credits.GSTHST},
+		{"Provincial sales tax credit", current.Credits.ProvincialSalesTax, next.Credits.ProvincialSalesTax},
+		{"CWB", current.Workers.Basic, next.Workers.Basic},
+		{"CWB disability supplement", current.Workers.Disability, next.Workers.Disability},
+	} {
+		if benefit.next < benefit.current {
+			names = append(names, benefit.name)
+		}
+	}
+	return names
+}
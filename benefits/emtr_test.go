@@ -0,0 +1,56 @@
+package benefits_test
+
+import (
+	"testing"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/benefits"
+	"github.com/stefanovazzocell/SalaryAdvisor/tax"
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+func TestMarginalRates(t *testing.T) {
+	household := benefits.Household{
+		Province: types.Ontario,
+		Parents:  []tax.Income{{}},
+		Children: []int{3},
+	}
+	points, err := household.MarginalRates(2024, 0, 20000*types.CashDollar, 50000*types.CashDollar, 5000*types.CashDollar)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(points) != 6 {
+		t.Fatalf("Expected 6 points, got %d", len(points))
+	}
+	overlapping := 0
+	for i, point := range points {
+		if point.Employment != 20000*types.CashDollar+types.Cash(i)*5000*types.CashDollar {
+			t.Fatalf("Unexpected employment %s for point %d", point.Employment, i)
+		}
+		if point.Rate < point.TaxRate || (len(point.PhaseOuts) > 0) != (point.Rate > point.TaxRate) {
+			t.Fatalf("Expected phase-outs to add to the tax rate, got %+v", point)
+		}
+		if point.Overlapping() {
+			overlapping++
+		}
+		// From $35'000 the CCB, child benefit, sales tax credits and CWB all phase out together
+		if len(point.PhaseOuts) > 2 && point.Rate < 55*types.PercentagePoint {
+			t.Fatalf("Expected stacked phase-outs to lose at least 55%% of the income, got %+v", point)
+		}
+	}
+	if overlapping != 5 {
+		t.Fatalf("Expected 5 points with overlapping phase-outs, got %+v", points)
+	}
+	if household.Parents[0].Employment != 0 {
+		t.Fatalf("Expected the household to be unchanged, got %s", household.Parents[0].Employment)
+	}
+
+	if _, err := household.MarginalRates(2024, 1, 0, 1, 1); err != benefits.ErrorUnknownParent {
+		t.Fatalf("Expected %v, got %v", benefits.ErrorUnknownParent, err)
+	}
+	if _, err := household.MarginalRates(2024, 0, 10, 0, 1); err != benefits.ErrorInvalidRange {
+		t.Fatalf("Expected %v, got %v", benefits.ErrorInvalidRange, err)
+	}
+	if _, err := household.MarginalRates(2024, 0, 0, 10, 0); err != benefits.ErrorInvalidRange {
+		t.Fatalf("Expected %v, got %v", benefits.ErrorInvalidRange, err)
+	}
+}
@@ -0,0 +1,174 @@
+package benefits
+
+import (
+	"errors"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/tax"
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+var (
+	ErrorUnknownParent  = errors.New("the household has no such parent")
+	ErrorInvalidParents = errors.New("a household must have one or two parents")
+)
+
+// A household of one or two parents or spouses, and their children
+type Household struct {
+	Province types.Province
+	Parents  []tax.Income // Income of each parent or spouse
+	Children []int        // Age of each child
+	Disabled int          // Number of parents eligible for the disability tax credit
+}
+
+// The taxes and benefits of a household for a year
+type HouseholdResult struct {
+	Taxes        []tax.Result // Taxes of each parent
+	PensionSplit types.Cash   // Pension income allocated from the first to the second parent
+	FamilyIncome types.Cash   // Adjusted family net income
+	Benefits     ChildBenefits
+	Credits      Credits
+	Workers      WorkersBenefit
+}
+
+// Returns the net pay of all parents plus the benefits and credits
+func (h HouseholdResult) Disposable() types.Cash {
+	return h.netPay() + h.transfers()
+}
+
+// Returns the benefits and credits of the household
+func (h HouseholdResult) transfers() types.Cash {
+	return h.Benefits.Total() + h.Credits.Total() + h.Workers.Total()
+}
+
+// Returns the net pay of all parents
+func (h HouseholdResult) netPay() types.Cash {
+	total := types.Cash(0)
+	for _, result := range h.Taxes {
+		total += result.NetPay()
+	}
+	return total
+}
+
+// Computes the taxes of the parents for a year and the benefits and credits based on their income.
+// Two parents are considered a married or common-law couple, claiming the spouse amount,
+// transferring unused credits and splitting pension income.
+func (h Household) Calculate(year types.Year) (HouseholdResult, error) {
+	if len(h.Parents) != 1 && len(h.Parents) != 2 {
+		return HouseholdResult{}, ErrorInvalidParents
+	}
+	if h.Disabled < 0 || h.Disabled > len(h.Parents) {
+		return HouseholdResult{}, ErrorInvalidDisabled
+	}
+	result := HouseholdResult{Taxes: make([]tax.Result, 0, len(h.Parents))}
+	if len(h.Parents) == 2 {
+		couple, err := tax.CalculateCouple(h.Province, year, h.Parents[0], h.Parents[1])
+		if err != nil {
+			return HouseholdResult{}, err
+		}
+		result.Taxes = append(result.Taxes, couple.First, couple.Second)
+		result.PensionSplit = couple.PensionSplit
+	} else {
+		for _, income := range h.Parents {
+			parent, err := tax.Calculate(h.Province, year, income)
+			if err != nil {
+				return HouseholdResult{}, err
+			}
+			result.Taxes = append(result.Taxes, parent)
+		}
+	}
+	for _, parent := range result.Taxes {
+		result.FamilyIncome += parent.NetIncome
+	}
+	var err error
+	if result.Benefits, err = ChildBenefit(h.Province, year, h.Children, result.FamilyIncome); err != nil {
+		return HouseholdResult{}, err
+	}
+	if result.Credits, err = SalesTaxCredits(h.Province, year, len(h.Parents) > 1, h.Children, result.FamilyIncome); err != nil {
+		return HouseholdResult{}, err
+	}
+	working := types.Cash(0)
+	for _, income := range h.Parents {
+		working += types.MaxCash(0, income.Employment+income.Business())
+	}
+	// Single parents get the family amounts for their eligible dependants
+	family := len(h.Parents) > 1
+	for _, age := range h.Children {
+		family = family || age < creditAdultAge
+	}
+	if result.Workers, err = CWB(year, family, working, result.FamilyIncome, h.Disabled); err != nil {
+		return HouseholdResult{}, err
+	}
+	return result, nil
+}
+
+// The value of a raise to a household
+type RaiseValue struct {
+	Raise    types.Cash // Raise in employment income
+	NetPay   types.Cash // Change of the net pay of the parent
+	Benefits types.Cash // Change of the benefits and credits, negative when clawed back
+	Value    types.Cash // Change of the disposable income of the household
+}
+
+// Returns the share of the raise lost to taxes, contributions and benefit reductions
+func (r RaiseValue) EffectiveRate() (types.Percentage, error) {
+	return (r.Raise - r.Value).FractionOf(r.Raise)
+}
+
+// Returns the value to the household of a raise in the employment income of a parent
+func (h Household) RaiseValue(year types.Year, parent int, raise types.Cash) (RaiseValue, error) {
+	if parent < 0 || parent >= len(h.Parents) {
+		return RaiseValue{}, ErrorUnknownParent
+	}
+	before, err := h.Calculate(year)
+	if err != nil {
+		return RaiseValue{}, err
+	}
+	parents := make([]tax.Income, len(h.Parents))
+	copy(parents, h.Parents)
+	parents[parent].Employment += raise
+	raised := h
+	raised.Parents = parents
+	after, err := raised.Calculate(year)
+	if err != nil {
+		return RaiseValue{}, err
+	}
+	return RaiseValue{
+		Raise:    raise,
+		NetPay:   after.Taxes[parent].NetPay() - before.Taxes[parent].NetPay(),
+		Benefits: after.transfers() - before.transfers(),
+		Value:    after.Disposable() - before.Disposable(),
+	}, nil
+}
+
+// The household before and after a parent changes jobs
+type JobChange struct {
+	Before HouseholdResult
+	After  HouseholdResult
+}
+
+// Returns the change of the disposable income of the household
+func (j JobChange) Difference() types.Cash {
+	return j.After.Disposable() - j.Before.Disposable()
+}
+
+// Computes what the household takes home together if a parent's employment income
+// is replaced by the one of a new job
+func (h Household) TakeJob(year types.Year, parent int, employment types.Cash) (JobChange, error) {
+	if parent < 0 || parent >= len(h.Parents) {
+		return JobChange{}, ErrorUnknownParent
+	}
+	before, err := h.Calculate(year)
+	if err != nil {
+		return JobChange{}, err
+	}
+	parents := make([]tax.Income, len(h.Parents))
+	copy(parents, h.Parents)
+	parents[parent].Employment = employment
+	changed := h
+	changed.Parents = parents
+	after, err := changed.Calculate(year)
+	if err != nil {
+		return JobChange{}, err
+	}
+	return JobChange{Before: before, After: after}, nil
+}
@@ -0,0 +1,141 @@
+package benefits_test
+
+import (
+	"testing"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/benefits"
+	"github.com/stefanovazzocell/SalaryAdvisor/tax"
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+func TestHouseholdCalculate(t *testing.T) {
+	household := benefits.Household{
+		Province: types.Ontario,
+		Parents: []tax.Income{
+			{Employment: 40000 * types.CashDollar},
+			{Employment: 30000 * types.CashDollar},
+		},
+		Children: []int{2, 7},
+	}
+	result, err := household.Calculate(2024)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(result.Taxes) != 2 || result.FamilyIncome != result.Taxes[0].NetIncome+result.Taxes[1].NetIncome {
+		t.Fatalf("Expected the family income to combine both parents, got %s", result.FamilyIncome)
+	}
+	expected, err := benefits.ChildBenefit(types.Ontario, 2024, []int{2, 7}, result.FamilyIncome)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Benefits != expected || expected.CCB == 0 {
+		t.Fatalf("Expected benefits %+v, got %+v", expected, result.Benefits)
+	}
+	credits, err := benefits.SalesTaxCredits(types.Ontario, 2024, true, []int{2, 7}, result.FamilyIncome)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Credits != credits {
+		t.Fatalf("Expected credits %+v, got %+v", credits, result.Credits)
+	}
+	if result.Disposable() != result.Taxes[0].NetPay()+result.Taxes[1].NetPay()+expected.Total()+credits.Total() {
+		t.Fatalf("Unexpected disposable income %s", result.Disposable())
+	}
+}
+
+func TestHouseholdParents(t *testing.T) {
+	for _, parents := range [][]tax.Income{nil, {{}, {}, {}}} {
+		household := benefits.Household{Province: types.Ontario, Parents: parents}
+		if _, err := household.Calculate(2024); err != benefits.ErrorInvalidParents {
+			t.Fatalf("Expected %v for %d parents, got %v", benefits.ErrorInvalidParents, len(parents), err)
+		}
+	}
+	single := benefits.Household{Province: types.Ontario, Parents: []tax.Income{{}}, Children: []int{3}, Disabled: 2}
+	if _, err := single.Calculate(2024); err != benefits.ErrorInvalidDisabled {
+		t.Fatalf("Expected %v for a single parent, got %v", benefits.ErrorInvalidDisabled, err)
+	}
+}
+
+func TestHouseholdSingleParent(t *testing.T) {
+	household := benefits.Household{
+		Province: types.Ontario,
+		Parents:  []tax.Income{{Employment: 20000 * types.CashDollar}},
+		Children: []int{3},
+	}
+	result, err := household.Calculate(2024)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// A single parent gets the family amounts of the Canada Workers Benefit
+	expected, err := benefits.CWB(2024, true, 20000*types.CashDollar, result.FamilyIncome, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Workers != expected || expected.Basic != 2739*types.CashDollar {
+		t.Fatalf("Expected the family CWB %+v, got %+v", expected, result.Workers)
+	}
+}
+
+func TestHouseholdRaiseValue(t *testing.T) {
+	household := benefits.Household{
+		Province: types.Ontario,
+		Parents:  []tax.Income{{Employment: 60000 * types.CashDollar}},
+		Children: []int{2},
+	}
+	value, err := household.RaiseValue(2024, 0, 5000*types.CashDollar)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// Part of the raise is clawed back from the CCB
+	if value.Benefits >= 0 || value.Value != value.NetPay+value.Benefits {
+		t.Fatalf("Expected a reduction of benefits, got %+v", value)
+	}
+	marginal, err := tax.MarginalRate(types.Ontario, 2024, household.Parents[0])
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	effective, err := value.EffectiveRate()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if effective <= marginal {
+		t.Fatalf("Expected an effective rate above the marginal rate %s, got %s", marginal, effective)
+	}
+	if household.Parents[0].Employment != 60000*types.CashDollar {
+		t.Fatalf("Expected the household to be unchanged, got %s", household.Parents[0].Employment)
+	}
+
+	if _, err := household.RaiseValue(2024, 1, types.CashDollar); err != benefits.ErrorUnknownParent {
+		t.Fatalf("Expected %v, got %v", benefits.ErrorUnknownParent, err)
+	}
+}
+
+func TestHouseholdTakeJob(t *testing.T) {
+	household := benefits.Household{
+		Province: types.Ontario,
+		Parents:  []tax.Income{{Employment: 90000 * types.CashDollar}, {}},
+	}
+	change, err := household.TakeJob(2024, 1, 60000*types.CashDollar)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// Spouses are taxed as a couple
+	couple, err := tax.CalculateCouple(types.Ontario, 2024, household.Parents[0], household.Parents[1])
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if change.Before.Taxes[0] != couple.First {
+		t.Fatalf("Expected %+v, got %+v", couple.First, change.Before.Taxes[0])
+	}
+	// The new job loses the spouse amount and some credits, on top of the taxes on it
+	if change.Difference() <= 0 || change.Difference() >= change.After.Taxes[1].NetPay() {
+		t.Fatalf("Expected a difference below the new net pay %s, got %s", change.After.Taxes[1].NetPay(), change.Difference())
+	}
+	if change.After.FamilyIncome != change.After.Taxes[0].NetIncome+change.After.Taxes[1].NetIncome {
+		t.Fatalf("Unexpected family income %s", change.After.FamilyIncome)
+	}
+
+	if _, err := household.TakeJob(2024, 2, 0); err != benefits.ErrorUnknownParent {
+		t.Fatalf("Expected %v, got %v", benefits.ErrorUnknownParent, err)
+	}
+}
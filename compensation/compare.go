@@ -0,0 +1,155 @@
+package compensation
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+var (
+	ErrorNotEnoughOffers = errors.New("at least two offers are needed for a comparison")
+)
+
+// An offer to compare
+type Offer struct {
+	Name    string
+	Package Package
+}
+
+// A stock price scenario
+type Scenario struct {
+	Name        string
+	StockGrowth types.Percentage // Yearly change of the stock price
+}
+
+// The outcome of an offer under a scenario
+type OfferOutcome struct {
+	Name       string
+	Years      []YearlyComp
+	Cumulative []types.Cash // Cumulative after-tax compensation at the end of each year
+	Diff       []types.Cash // Yearly after-tax compensation minus the one of the first offer
+	BreakEven  types.Year   // First year from which the cumulative compensation stays strictly ahead of the first offer, 0 if never
+}
+
+// Returns the total after-tax compensation over the projection
+func (o OfferOutcome) Total() types.Cash {
+	if len(o.Cumulative) == 0 {
+		return 0
+	}
+	return o.Cumulative[len(o.Cumulative)-1]
+}
+
+// The outcome of all the offers under a scenario
+type ScenarioOutcome struct {
+	Scenario Scenario
+	Offers   []OfferOutcome // In the same order as the compared offers
+	Winner   string         // Name of the offer with the highest total after-tax compensation
+}
+
+// The position of an offer across all scenarios
+type Rank struct {
+	Name         string
+	Wins         int        // Number of scenarios in which the offer wins
+	AverageTotal types.Cash // Average total after-tax compensation across scenarios
+}
+
+// The comparison of multiple offers
+type Comparison struct {
+	Scenarios []ScenarioOutcome
+	Ranking   []Rank // Sorted from best to worst by wins, then by average total
+}
+
+// Compares offers over the projection, under each stock scenario.
+// The first offer is the baseline for differences and break-even points.
+// If no scenario is given, the stock growth of the projection is used.
+func Compare(offers []Offer, projection Projection, scenarios []Scenario) (Comparison, error) {
+	if len(offers) < 2 {
+		return Comparison{}, ErrorNotEnoughOffers
+	}
+	if len(scenarios) == 0 {
+		scenarios = []Scenario{{Name: "base", StockGrowth: projection.StockGrowth}}
+	}
+	comparison := Comparison{
+		Scenarios: make([]ScenarioOutcome, 0, len(scenarios)),
+		Ranking:   make([]Rank, len(offers)),
+	}
+	for i, offer := range offers {
+		comparison.Ranking[i].Name = offer.Name
+	}
+	for _, scenario := range scenarios {
+		projection.StockGrowth = scenario.StockGrowth
+		outcome, err := compareScenario(offers, projection)
+		if err != nil {
+			return Comparison{}, err
+		}
+		outcome.Scenario = scenario
+		// Update the ranking
+		best := 0
+		for i, offerOutcome := range outcome.Offers {
+			comparison.Ranking[i].AverageTotal += offerOutcome.Total() / types.Cash(len(scenarios))
+			if offerOutcome.Total() > outcome.Offers[best].Total() {
+				best = i
+			}
+		}
+		comparison.Ranking[best].Wins++
+		outcome.Winner = outcome.Offers[best].Name
+		comparison.Scenarios = append(comparison.Scenarios, outcome)
+	}
+	sort.SliceStable(comparison.Ranking, func(i, j int) bool {
+		if comparison.Ranking[i].Wins != comparison.Ranking[j].Wins {
+			return comparison.Ranking[i].Wins > comparison.Ranking[j].Wins
+		}
+		return comparison.Ranking[i].AverageTotal > comparison.Ranking[j].AverageTotal
+	})
+	return comparison, nil
+}
+
+// Projects all the offers and compares them with the first one
+func compareScenario(offers []Offer, projection Projection) (ScenarioOutcome, error) {
+	outcome := ScenarioOutcome{
+		Offers: make([]OfferOutcome, 0, len(offers)),
+	}
+	for _, offer := range offers {
+		years, err := offer.Package.Project(projection)
+		if err != nil {
+			return ScenarioOutcome{}, err
+		}
+		outcome.Offers = append(outcome.Offers, newOfferOutcome(offer.Name, years))
+	}
+	baseline := outcome.Offers[0]
+	for i := range outcome.Offers {
+		outcome.Offers[i].compareWith(baseline, i == 0)
+	}
+	return outcome, nil
+}
+
+// Returns the outcome of an offer, without the comparison with the baseline
+func newOfferOutcome(name string, years []YearlyComp) OfferOutcome {
+	outcome := OfferOutcome{
+		Name:       name,
+		Years:      years,
+		Cumulative: make([]types.Cash, len(years)),
+	}
+	cumulative := types.Cash(0)
+	for i, year := range years {
+		cumulative += year.AfterTax()
+		outcome.Cumulative[i] = cumulative
+	}
+	return outcome
+}
+
+// Computes the differences and the break-even point with the baseline,
+// which never breaks even with itself
+func (o *OfferOutcome) compareWith(baseline OfferOutcome, isBaseline bool) {
+	o.Diff = make([]types.Cash, len(o.Years))
+	o.BreakEven = 0
+	for i, year := range o.Years {
+		o.Diff[i] = year.AfterTax() - baseline.Years[i].AfterTax()
+		if isBaseline || o.Cumulative[i] <= baseline.Cumulative[i] {
+			o.BreakEven = 0
+		} else if o.BreakEven == 0 {
+			o.BreakEven = year.Year
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package compensation_test
+
+import (
+	"testing"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/compensation"
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+func TestCompare(t *testing.T) {
+	offers := []compensation.Offer{
+		{Name: "cash", Package: compensation.Package{BaseSalary: 150000 * types.CashDollar}},
+		{Name: "stock", Package: compensation.Package{
+			BaseSalary: 120000 * types.CashDollar,
+			Grants: []compensation.RSUGrant{
+				{Value: types.NewStock(200000*types.CashDollar, 0), VestYears: 4, FirstVest: 1},
+			},
+		}},
+	}
+	projection := compensation.Projection{Province: types.Ontario, Start: 2024, Years: 5}
+	scenarios := []compensation.Scenario{
+		{Name: "bust", StockGrowth: -50 * types.PercentagePoint},
+		{Name: "flat"},
+		{Name: "boom", StockGrowth: 30 * types.PercentagePoint},
+	}
+	comparison, err := compensation.Compare(offers, projection, scenarios)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(comparison.Scenarios) != 3 {
+		t.Fatalf("Expected 3 scenarios, got %d", len(comparison.Scenarios))
+	}
+	expected := map[string]struct {
+		winner      string
+		breaksEven  bool
+		firstBehind bool
+	}{
+		"bust": {"cash", false, true},
+		"flat": {"stock", true, true},
+		"boom": {"stock", true, true},
+	}
+	for _, outcome := range comparison.Scenarios {
+		name := outcome.Scenario.Name
+		if outcome.Winner != expected[name].winner {
+			t.Fatalf("Expected %q to win under %q, got %q", expected[name].winner, name, outcome.Winner)
+		}
+		baseline, stock := outcome.Offers[0], outcome.Offers[1]
+		if baseline.BreakEven != 0 {
+			t.Fatalf("Expected the baseline not to break even with itself, got %d", baseline.BreakEven)
+		}
+		if breaksEven := stock.BreakEven != 0; breaksEven != expected[name].breaksEven {
+			t.Fatalf("Expected break-even %v under %q, got %d", expected[name].breaksEven, name, stock.BreakEven)
+		}
+		if expected[name].breaksEven && (stock.BreakEven <= 2024 || stock.BreakEven > 2028) {
+			t.Fatalf("Expected the stock offer to break even after the cliff under %q, got %d", name, stock.BreakEven)
+		}
+		if firstBehind := stock.Diff[0] < 0; firstBehind != expected[name].firstBehind {
+			t.Fatalf("Unexpected first year difference %s under %q", stock.Diff[0], name)
+		}
+		total := types.Cash(0)
+		for i, diff := range stock.Diff {
+			total += diff
+			if stock.Cumulative[i]-baseline.Cumulative[i] != total {
+				t.Fatalf("Cumulative values do not match differences under %q", name)
+			}
+		}
+		if stock.Total() != stock.Cumulative[4] {
+			t.Fatalf("Unexpected total %s under %q", stock.Total(), name)
+		}
+	}
+	if comparison.Ranking[0].Name != "stock" || comparison.Ranking[0].Wins != 2 ||
+		comparison.Ranking[1].Name != "cash" || comparison.Ranking[1].Wins != 1 {
+		t.Fatalf("Unexpected ranking %+v", comparison.Ranking)
+	}
+
+	// Without scenarios, the projection is used
+	comparison, err = compensation.Compare(offers, projection, nil)
+	if err != nil || len(comparison.Scenarios) != 1 || comparison.Scenarios[0].Winner != "stock" {
+		t.Fatalf("Unexpected comparison without scenarios %+v, %v", comparison, err)
+	}
+	// A tie is not ahead
+	tie, err := compensation.Compare([]compensation.Offer{offers[0], offers[0]}, projection, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if breakEven := tie.Scenarios[0].Offers[1].BreakEven; breakEven != 0 {
+		t.Fatalf("Expected identical offers not to break even, got %d", breakEven)
+	}
+	if _, err := compensation.Compare(offers[:1], projection, scenarios); err != compensation.ErrorNotEnoughOffers {
+		t.Fatalf("Expected ErrorNotEnoughOffers, got %v", err)
+	}
+}
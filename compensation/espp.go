@@ -0,0 +1,136 @@
+package compensation
+
+import (
+	"errors"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+var (
+	ErrorInvalidPrice = errors.New("stock prices must be positive")
+)
+
+// ESPP represents an Employee Stock Purchase Plan
+type ESPP struct {
+	Contribution types.Percentage // Percentage of the salary contributed to the plan
+	Discount     types.Percentage // Discount applied to the purchase price
+	Lookback     bool             // If true, the purchase price is based on the lowest between start and end price
+	Cap          types.Cash       // Maximum contribution per offering period (0 means no cap)
+}
+
+// A single offering period of an ESPP
+type OfferingPeriod struct {
+	Salary     types.Cash  // Salary earned during the offering period
+	StartPrice types.Stock // Price of one share at the start of the offering period
+	EndPrice   types.Stock // Price of one share on the purchase date
+}
+
+// The outcome of an offering period
+type OfferingResult struct {
+	Contribution   types.Cash  // Amount withheld from salary and used to buy shares
+	PurchasePrice  types.Stock // Discounted price paid for one share
+	Purchased      types.Stock // Shares purchased, at their market value on the purchase date
+	TaxableBenefit types.Cash  // Employment benefit: market value minus the price paid
+	NetGain        types.Cash  // Taxable benefit after tax at the marginal rate
+}
+
+// Totals over multiple offering periods
+type ESPPSummary struct {
+	Contribution   types.Cash
+	TaxableBenefit types.Cash
+	NetGain        types.Cash
+}
+
+// Computes the result of a single offering period given the marginal tax rate
+// that applies to the taxable benefit
+func (e ESPP) Offering(period OfferingPeriod, marginalRate types.Percentage) (OfferingResult, error) {
+	// Compute the contribution
+	contribution, err := period.Salary.Percentage(e.Contribution)
+	if err != nil {
+		return OfferingResult{}, err
+	}
+	if e.Cap > 0 && contribution > e.Cap {
+		contribution = e.Cap
+	}
+	// Find the purchase price, in the stock currency
+	_, startPrice, _, err := period.StartPrice.Value()
+	if err != nil {
+		return OfferingResult{}, err
+	}
+	_, endPrice, _, err := period.EndPrice.Value()
+	if err != nil {
+		return OfferingResult{}, err
+	}
+	if endPrice <= 0 || (e.Lookback && startPrice <= 0) {
+		return OfferingResult{}, ErrorInvalidPrice
+	}
+	basePrice := endPrice
+	if e.Lookback && startPrice < endPrice {
+		basePrice = startPrice
+	}
+	purchasePrice, err := basePrice.Percentage(100*types.PercentagePoint - e.Discount)
+	if err != nil {
+		return OfferingResult{}, err
+	}
+	if purchasePrice <= 0 {
+		return OfferingResult{}, ErrorInvalidPrice
+	}
+	// Buy as many shares as the contribution allows
+	conversion := period.EndPrice.Conversion()
+	contributionOrig, err := contribution.MulDiv(types.CashDollar, conversion)
+	if err != nil {
+		return OfferingResult{}, err
+	}
+	quantity, err := contributionOrig.MulDiv(types.Cash(types.ShareUnit), purchasePrice)
+	if err != nil {
+		return OfferingResult{}, err
+	}
+	purchased, err := types.NewStockShares(types.Shares(quantity), endPrice, conversion)
+	if err != nil {
+		return OfferingResult{}, err
+	}
+	purchasedCAD, _, _, err := purchased.Value()
+	if err != nil {
+		return OfferingResult{}, err
+	}
+	// Fractional shares are bought, so the cost matches the contribution
+	// up to the rounding to the smallest fraction of a share
+	cost, err := types.NewStockShares(types.Shares(quantity), purchasePrice, conversion)
+	if err != nil {
+		return OfferingResult{}, err
+	}
+	costCAD, _, _, err := cost.Value()
+	if err != nil {
+		return OfferingResult{}, err
+	}
+	// Compute benefit and tax
+	benefit := purchasedCAD - costCAD
+	tax, err := benefit.Percentage(marginalRate)
+	if err != nil {
+		return OfferingResult{}, err
+	}
+	return OfferingResult{
+		Contribution:   contribution,
+		PurchasePrice:  types.NewStock(purchasePrice, conversion),
+		Purchased:      purchased,
+		TaxableBenefit: benefit,
+		NetGain:        benefit - tax,
+	}, nil
+}
+
+// Computes the results for a series of offering periods, and their totals
+func (e ESPP) Simulate(periods []OfferingPeriod, marginalRate types.Percentage) ([]OfferingResult, ESPPSummary, error) {
+	results := make([]OfferingResult, 0, len(periods))
+	summary := ESPPSummary{}
+	for _, period := range periods {
+		result, err := e.Offering(period, marginalRate)
+		if err != nil {
+			return nil, ESPPSummary{}, err
+		}
+		results = append(results, result)
+		summary.Contribution += result.Contribution
+		summary.TaxableBenefit += result.TaxableBenefit
+		summary.NetGain += result.NetGain
+	}
+	return results, summary, nil
+}
@@ -0,0 +1,91 @@
+package compensation_test
+
+import (
+	"testing"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/compensation"
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+func TestESPPOffering(t *testing.T) {
+	usd := func(price types.Cash) types.Stock {
+		return types.NewStock(price*types.CashDollar, 13600) // 1.36 CAD/USD
+	}
+	period := compensation.OfferingPeriod{
+		Salary:     50000 * types.CashDollar,
+		StartPrice: usd(100),
+		EndPrice:   usd(120),
+	}
+	testCases := map[compensation.ESPP]struct {
+		contribution types.Cash
+		purchaseUSD  types.Cash
+		benefit      types.Cash
+		netGain      types.Cash
+	}{
+		{10 * types.PercentagePoint, 15 * types.PercentagePoint, true, 0}:                       {5000 * types.CashDollar, 85 * types.CashDollar, 20588190, 12352914},
+		{10 * types.PercentagePoint, 15 * types.PercentagePoint, false, 0}:                      {5000 * types.CashDollar, 102 * types.CashDollar, 8823522, 5294114},
+		{10 * types.PercentagePoint, 15 * types.PercentagePoint, true, 3000 * types.CashDollar}: {3000 * types.CashDollar, 85 * types.CashDollar, 12352914, 7411749},
+		{10 * types.PercentagePoint, 0, false, 0}:                                               {5000 * types.CashDollar, 120 * types.CashDollar, 0, 0},
+		{0, 15 * types.PercentagePoint, true, 0}:                                                {0, 85 * types.CashDollar, 0, 0},
+	}
+
+	for espp, expected := range testCases {
+		result, err := espp.Offering(period, 40*types.PercentagePoint)
+		if err != nil {
+			t.Fatalf("Unexpected error for %v: %v", espp, err)
+		}
+		if result.Contribution != expected.contribution {
+			t.Fatalf("Expected contribution %s for %v, instead got %s", expected.contribution, espp, result.Contribution)
+		}
+		if _, purchaseUSD, _, _ := result.PurchasePrice.Value(); purchaseUSD != expected.purchaseUSD {
+			t.Fatalf("Expected purchase price %s for %v, instead got %s", expected.purchaseUSD, espp, purchaseUSD)
+		}
+		if result.TaxableBenefit != expected.benefit {
+			t.Fatalf("Expected benefit %d for %v, instead got %d", expected.benefit, espp, result.TaxableBenefit)
+		}
+		if result.NetGain != expected.netGain {
+			t.Fatalf("Expected net gain %d for %v, instead got %d", expected.netGain, espp, result.NetGain)
+		}
+		if cad, _, _, _ := result.Purchased.Value(); cad-result.Contribution-result.TaxableBenefit > types.CashCent {
+			t.Fatalf("Expected purchased value %s to match contribution plus benefit for %v", cad, espp)
+		}
+		if price := result.Purchased.Price(); price != 120*types.CashDollar && result.Contribution != 0 {
+			t.Fatalf("Expected purchased shares to be valued at 120, instead got %s for %v", price, espp)
+		}
+	}
+}
+
+func TestESPPSimulate(t *testing.T) {
+	espp := compensation.ESPP{
+		Contribution: 10 * types.PercentagePoint,
+		Discount:     15 * types.PercentagePoint,
+		Lookback:     true,
+	}
+	periods := []compensation.OfferingPeriod{
+		{Salary: 60000 * types.CashDollar, StartPrice: types.NewStock(100*types.CashDollar, 0), EndPrice: types.NewStock(80*types.CashDollar, 0)},
+		{Salary: 60000 * types.CashDollar, StartPrice: types.NewStock(80*types.CashDollar, 0), EndPrice: types.NewStock(100*types.CashDollar, 0)},
+	}
+	results, summary, err := espp.Simulate(periods, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	// Both periods purchase at a 15% discount, the second also benefits from the lookback
+	if summary.Contribution != 12000*types.CashDollar {
+		t.Fatalf("Expected total contribution of 12'000, got %s", summary.Contribution)
+	}
+	if summary.TaxableBenefit != results[0].TaxableBenefit+results[1].TaxableBenefit ||
+		results[1].TaxableBenefit <= results[0].TaxableBenefit {
+		t.Fatalf("Unexpected benefits %s + %s = %s", results[0].TaxableBenefit, results[1].TaxableBenefit, summary.TaxableBenefit)
+	}
+	if summary.NetGain != summary.TaxableBenefit {
+		t.Fatalf("Expected no tax with a 0%% marginal rate, got %s net on %s", summary.NetGain, summary.TaxableBenefit)
+	}
+	// Invalid prices
+	periods[0].EndPrice = types.Stock{}
+	if _, _, err := espp.Simulate(periods, 0); err != compensation.ErrorInvalidPrice {
+		t.Fatalf("Expected ErrorInvalidPrice, got %v", err)
+	}
+}
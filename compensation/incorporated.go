@@ -0,0 +1,113 @@
+package compensation
+
+import (
+	"errors"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/tax"
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+var (
+	ErrorSalaryExceedsIncome = errors.New("the salary and employer contributions exceed the income of the corporation")
+	ErrorInvalidPayout       = errors.New("the dividend payout must be between 0% and 100%")
+)
+
+// A contractor billing through their own Canadian-controlled private corporation
+type Incorporated struct {
+	Revenue        types.Cash       // Yearly revenue billed by the corporation
+	Expenses       types.Cash       // Yearly deductible business expenses
+	Salary         types.Cash       // Yearly salary paid to the owner
+	DividendPayout types.Percentage // Share of the income left after corporate tax paid as dividends
+}
+
+// The outcome of a year of billing through a corporation
+type IncorporatedYear struct {
+	Year                 types.Year
+	Salary               types.Cash
+	EmployerCPP          types.Cash // CPP or QPP contributions paid by the corporation on the salary
+	Corporate            tax.CorporateResult
+	EligibleDividends    types.Cash // Dividends paid out of the general rate income pool
+	NonEligibleDividends types.Cash
+	Retained             types.Cash // Income left in the corporation after taxes and dividends
+	Tax                  tax.Result // Personal taxes of the owner
+}
+
+// Returns the income received by the owner after personal taxes and contributions
+func (y IncorporatedYear) TakeHome() types.Cash {
+	return y.Tax.NetPay()
+}
+
+// Computes corporate and personal taxes for a year, for an owner resident in the province.
+// The owner is not insurable for EI, and eligible dividends are paid first when possible.
+func (c Incorporated) Year(province types.Province, year types.Year) (IncorporatedYear, error) {
+	if c.DividendPayout < 0 || c.DividendPayout > 100*types.PercentagePoint {
+		return IncorporatedYear{}, ErrorInvalidPayout
+	}
+	salaryOnly, err := tax.Calculate(province, year, tax.Income{Employment: c.Salary, ExemptFromEI: true})
+	if err != nil {
+		return IncorporatedYear{}, err
+	}
+	result := IncorporatedYear{
+		Year:        year,
+		Salary:      c.Salary,
+		EmployerCPP: salaryOnly.CPP,
+	}
+	income := c.Revenue - c.Expenses - c.Salary - result.EmployerCPP
+	if income < 0 {
+		return IncorporatedYear{}, ErrorSalaryExceedsIncome
+	}
+	if result.Corporate, err = tax.CorporateTax(province, year, income); err != nil {
+		return IncorporatedYear{}, err
+	}
+	afterTax := result.Corporate.AfterTax()
+	dividends, err := afterTax.Percentage(c.DividendPayout)
+	if err != nil {
+		return IncorporatedYear{}, err
+	}
+	result.EligibleDividends = dividends
+	if result.EligibleDividends > result.Corporate.GRIP {
+		result.EligibleDividends = result.Corporate.GRIP
+	}
+	result.NonEligibleDividends = dividends - result.EligibleDividends
+	result.Retained = afterTax - dividends
+	result.Tax, err = tax.Calculate(province, year, tax.Income{
+		Employment:           c.Salary,
+		EligibleDividends:    result.EligibleDividends,
+		NonEligibleDividends: result.NonEligibleDividends,
+		ExemptFromEI:         true,
+	})
+	if err != nil {
+		return IncorporatedYear{}, err
+	}
+	return result, nil
+}
+
+// A year of billing through a corporation compared to working as an employee
+type IncorporationComparison struct {
+	Employee     YearlyComp
+	Incorporated IncorporatedYear
+	Difference   types.Cash // Take-home pay of the owner minus the after-tax compensation of the employee
+}
+
+// Compares billing through a corporation with an employee package over the projection.
+// The after-tax compensation of the employee includes benefits and employer contributions,
+// which a contractor would have to pay for out of their take-home pay.
+func CompareIncorporation(contract Incorporated, employee Package, projection Projection) ([]IncorporationComparison, error) {
+	years, err := employee.Project(projection)
+	if err != nil {
+		return nil, err
+	}
+	comparison := make([]IncorporationComparison, 0, len(years))
+	for _, year := range years {
+		incorporated, err := contract.Year(projection.ResidenceIn(year.Year), year.Year)
+		if err != nil {
+			return nil, err
+		}
+		comparison = append(comparison, IncorporationComparison{
+			Employee:     year,
+			Incorporated: incorporated,
+			Difference:   incorporated.TakeHome() - year.AfterTax(),
+		})
+	}
+	return comparison, nil
+}
@@ -0,0 +1,86 @@
+package compensation_test
+
+import (
+	"testing"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/compensation"
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+func TestIncorporatedYear(t *testing.T) {
+	contract := compensation.Incorporated{
+		Revenue:        200000 * types.CashDollar,
+		Expenses:       10000 * types.CashDollar,
+		Salary:         60000 * types.CashDollar,
+		DividendPayout: 50 * types.PercentagePoint,
+	}
+	year, err := contract.Year(types.Ontario, 2024)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if year.EmployerCPP != year.Tax.CPP || year.Tax.EI != 0 {
+		t.Fatalf("Expected the employer to match %s of CPP and no EI, got %s and %s", year.Tax.CPP, year.EmployerCPP, year.Tax.EI)
+	}
+	if year.Corporate.Income != 130000*types.CashDollar-year.EmployerCPP {
+		t.Fatalf("Unexpected corporate income %s", year.Corporate.Income)
+	}
+	// All the income is below the business limit, so no eligible dividends can be paid
+	if year.EligibleDividends != 0 || year.NonEligibleDividends == 0 {
+		t.Fatalf("Expected only non-eligible dividends, got %s and %s", year.EligibleDividends, year.NonEligibleDividends)
+	}
+	if year.NonEligibleDividends+year.Retained != year.Corporate.AfterTax() {
+		t.Fatalf("Expected %s to be split between dividends and retained earnings, got %s and %s",
+			year.Corporate.AfterTax(), year.NonEligibleDividends, year.Retained)
+	}
+	if year.TakeHome() != year.Tax.NetPay() || year.TakeHome() >= year.Salary+year.NonEligibleDividends {
+		t.Fatalf("Unexpected take-home pay %s", year.TakeHome())
+	}
+
+	// Above the business limit, dividends are eligible first
+	contract = compensation.Incorporated{Revenue: 700000 * types.CashDollar, DividendPayout: 100 * types.PercentagePoint}
+	if year, err = contract.Year(types.Ontario, 2024); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if year.EligibleDividends != year.Corporate.GRIP || year.Retained != 0 {
+		t.Fatalf("Expected %s of eligible dividends and nothing retained, got %s and %s",
+			year.Corporate.GRIP, year.EligibleDividends, year.Retained)
+	}
+
+	contract = compensation.Incorporated{Revenue: 50000 * types.CashDollar, Salary: 50000 * types.CashDollar}
+	if _, err = contract.Year(types.Ontario, 2024); err != compensation.ErrorSalaryExceedsIncome {
+		t.Fatalf("Expected %v, got %v", compensation.ErrorSalaryExceedsIncome, err)
+	}
+	contract = compensation.Incorporated{Revenue: 50000 * types.CashDollar, DividendPayout: 101 * types.PercentagePoint}
+	if _, err = contract.Year(types.Ontario, 2024); err != compensation.ErrorInvalidPayout {
+		t.Fatalf("Expected %v, got %v", compensation.ErrorInvalidPayout, err)
+	}
+}
+
+func TestCompareIncorporation(t *testing.T) {
+	contract := compensation.Incorporated{
+		Revenue:        150000 * types.CashDollar,
+		Salary:         70000 * types.CashDollar,
+		DividendPayout: 100 * types.PercentagePoint,
+	}
+	employee := compensation.Package{BaseSalary: 150000 * types.CashDollar, Benefits: 5000 * types.CashDollar}
+	projection := compensation.Projection{Province: types.Ontario, Start: 2024, Years: 2}
+	comparison, err := compensation.CompareIncorporation(contract, employee, projection)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(comparison) != 2 {
+		t.Fatalf("Expected 2 years, got %d", len(comparison))
+	}
+	for _, year := range comparison {
+		if year.Employee.Year != year.Incorporated.Year {
+			t.Fatalf("Expected the same year, got %d and %d", year.Employee.Year, year.Incorporated.Year)
+		}
+		if year.Difference != year.Incorporated.TakeHome()-year.Employee.AfterTax() {
+			t.Fatalf("Unexpected difference %s in %d", year.Difference, year.Employee.Year)
+		}
+		// The employee has no employer CPP or corporate tax, but receives benefits
+		if year.Difference >= 0 {
+			t.Fatalf("Expected the employee to come ahead at the same cost, got %s in %d", year.Difference, year.Employee.Year)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package compensation
+
+import (
+	"errors"
+	"time"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/tax"
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+var (
+	ErrorInvalidHorizon = errors.New("the projection must cover at least one year")
+	ErrorInvalidVesting = errors.New("grants must vest over at least one year")
+	ErrorSignOnOutside  = errors.New("the sign-on bonus is paid outside the years of the projection")
+)
+
+// A grant of restricted stock units vesting in equal yearly parts
+type RSUGrant struct {
+	Value     types.Stock // Value of the whole grant, at the price of the first year
+	VestYears int         // Number of yearly vests
+	FirstVest int         // Year of the first vest, relative to the first year (0 == first year)
+}
+
+// Package represents a total compensation package
+type Package struct {
+	BaseSalary  types.Cash
+	AnnualRaise types.Percentage // Yearly raise on the base salary
+	BonusTarget types.Percentage // Target bonus, as a percentage of the base salary
+	Grants      []RSUGrant
+	SignOn      SignOnBonus
+	Retirement  RetirementPlan // Employer RRSP, pension or profit sharing plan
+	Pension     DefinedBenefit // Defined benefit pension plan
+	Benefits    types.Cash     // Yearly value of non-taxable benefits, such as health and dental coverage
+	Allowances  types.Cash     // Yearly taxable allowances
+}
+
+// A change of the province of residence
+type Move struct {
+	To   types.Province
+	Date time.Time
+}
+
+// The assumptions used to project a package over multiple years
+type Projection struct {
+	Province    types.Province   // Province of residence at the start
+	Moves       []Move           // Later changes of the province of residence
+	Start       types.Year       // First year of the projection
+	Years       int              // Number of years in the projection
+	StockGrowth types.Percentage // Yearly change of the stock price
+	Age         int              // Age at the start, required to value defined benefit pensions
+}
+
+// Returns the province of residence on December 31 of the given year,
+// which determines the provincial tax for the whole year
+func (p Projection) ResidenceIn(year types.Year) types.Province {
+	province := p.Province
+	latest := time.Time{}
+	for _, move := range p.Moves {
+		if types.Year(move.Date.Year()) <= year && !move.Date.Before(latest) {
+			province = move.To
+			latest = move.Date
+		}
+	}
+	return province
+}
+
+// The compensation received in a single year
+type YearlyComp struct {
+	Year       types.Year
+	Salary     types.Cash
+	Bonus      types.Cash
+	Stock      types.Cash // Value of the units vesting in the year
+	SignOn     types.Cash // Sign-on installments, net of repayments
+	Allowances types.Cash
+	Retirement RetirementContributions
+	Pension    PensionAccrual
+	Benefits   types.Cash
+	Tax        tax.Result
+}
+
+// Returns the total gross compensation
+func (y YearlyComp) Gross() types.Cash {
+	return y.Salary + y.Bonus + y.Stock + y.SignOn + y.Allowances + y.Retirement.Employer + y.Pension.Employer() + y.Benefits
+}
+
+// Returns the compensation subject to income tax, before deducting retirement contributions
+func (y YearlyComp) Taxable() types.Cash {
+	return y.Salary + y.Bonus + y.Stock + y.SignOn + y.Allowances + y.Retirement.TaxableBenefit
+}
+
+// Returns the total compensation after income tax and payroll contributions,
+// including the retirement contributions of both the employee and employer
+func (y YearlyComp) AfterTax() types.Cash {
+	return y.Gross() - y.Tax.IncomeTax() - y.Tax.Contributions()
+}
+
+// Projects the package over the years, assuming the employee stays for the whole projection.
+// A sign-on bonus without a start date starts on January 1 of the first year,
+// and its installments must be paid within the projection.
+func (p Package) Project(projection Projection) ([]YearlyComp, error) {
+	if projection.Years <= 0 {
+		return nil, ErrorInvalidHorizon
+	}
+	// Without a start date, the employment starts with the projection
+	bonus := p.SignOn
+	if bonus.Start.IsZero() {
+		bonus.Start = time.Date(int(projection.Start), time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+	signOn, err := bonus.Stay(projection.Years * 12)
+	if err != nil {
+		return nil, err
+	}
+	for _, installment := range bonus.Installments {
+		year := types.Year(bonus.Start.AddDate(0, installment.Month, 0).Year())
+		if installment.Amount != 0 && (year < projection.Start || year >= projection.Start+types.Year(projection.Years)) {
+			return nil, ErrorSignOnOutside
+		}
+	}
+	growth := 100*types.PercentagePoint + projection.StockGrowth
+	raise := 100*types.PercentagePoint + p.AnnualRaise
+	salary := p.BaseSalary
+	salaries := make([]types.Cash, 0, projection.Years)
+	stockFactor := 100 * types.PercentagePoint
+	years := make([]YearlyComp, 0, projection.Years)
+	for i := 0; i < projection.Years; i++ {
+		year := YearlyComp{
+			Year:       projection.Start + types.Year(i),
+			Salary:     salary,
+			SignOn:     signOn.ByYear[projection.Start+types.Year(i)],
+			Allowances: p.Allowances,
+			Benefits:   p.Benefits,
+		}
+		if year.Bonus, err = salary.Percentage(p.BonusTarget); err != nil {
+			return nil, err
+		}
+		if year.Retirement, err = p.Retirement.Contributions(salary); err != nil {
+			return nil, err
+		}
+		salaries = append(salaries, salary)
+		if year.Pension, err = p.Pension.accrual(salaries, projection.Age+i); err != nil {
+			return nil, err
+		}
+		if year.Stock, err = p.vesting(i, stockFactor); err != nil {
+			return nil, err
+		}
+		year.Tax, err = tax.Calculate(projection.ResidenceIn(year.Year), year.Year, tax.Income{
+			Employment: year.Taxable(),
+			Deductions: year.Retirement.Deduction + year.Pension.Contribution,
+		})
+		if err != nil {
+			return nil, err
+		}
+		years = append(years, year)
+		// Prepare the next year
+		if salary, err = salary.Percentage(raise); err != nil {
+			return nil, err
+		}
+		factor, err := types.Cash(stockFactor).Percentage(growth)
+		if err != nil {
+			return nil, err
+		}
+		stockFactor = types.Percentage(factor)
+	}
+	return years, nil
+}
+
+// Returns the value in CAD of the units vesting in the i-th year,
+// given the change in stock price since the first year
+func (p Package) vesting(i int, stockFactor types.Percentage) (types.Cash, error) {
+	total := types.Cash(0)
+	for _, grant := range p.Grants {
+		if grant.VestYears <= 0 {
+			return 0, ErrorInvalidVesting
+		}
+		if i < grant.FirstVest || i >= grant.FirstVest+grant.VestYears {
+			continue
+		}
+		value, _, _, err := grant.Value.Value()
+		if err != nil {
+			return 0, err
+		}
+		vest, err := value.Percentage(stockFactor)
+		if err != nil {
+			return 0, err
+		}
+		total += vest / types.Cash(grant.VestYears)
+	}
+	return total, nil
+}
+
+// Returns the total gross and after-tax compensation over multiple years
+func Total(years []YearlyComp) (gross types.Cash, afterTax types.Cash) {
+	for _, year := range years {
+		gross += year.Gross()
+		afterTax += year.AfterTax()
+	}
+	return gross, afterTax
+}
@@ -0,0 +1,97 @@
+package compensation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/compensation"
+	"github.com/stefanovazzocell/SalaryAdvisor/tax"
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+func TestPackageProject(t *testing.T) {
+	offer := compensation.Package{
+		BaseSalary:  150000 * types.CashDollar,
+		AnnualRaise: 3 * types.PercentagePoint,
+		BonusTarget: 10 * types.PercentagePoint,
+		Grants: []compensation.RSUGrant{
+			{Value: types.NewStock(100000*types.CashDollar, 13600), VestYears: 4},
+		},
+		SignOn: compensation.SignOnBonus{
+			Start:          time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC),
+			Installments:   []compensation.Installment{{Month: 0, Amount: 20000 * types.CashDollar}},
+			ClawbackMonths: 12,
+		},
+		Retirement: compensation.RetirementPlan{Kind: compensation.DCPP, EmployerBase: 5 * types.PercentagePoint},
+		Benefits:   3000 * types.CashDollar,
+		Allowances: 1200 * types.CashDollar,
+	}
+	years, err := offer.Project(compensation.Projection{
+		Province:    types.Ontario,
+		Start:       2024,
+		Years:       5,
+		StockGrowth: 10 * types.PercentagePoint,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(years) != 5 {
+		t.Fatalf("Expected 5 years, got %d", len(years))
+	}
+	expected := []struct {
+		year   types.Year
+		salary types.Cash
+		stock  types.Cash
+		signOn types.Cash
+	}{
+		{2024, 150000 * types.CashDollar, 34000 * types.CashDollar, 20000 * types.CashDollar},
+		{2025, 154500 * types.CashDollar, 37400 * types.CashDollar, 0},
+		{2026, 159135 * types.CashDollar, 41140 * types.CashDollar, 0},
+		{2027, 16390905 * types.CashCent, 45254 * types.CashDollar, 0},
+		{2028, 1688263215, 0, 0},
+	}
+	for i, year := range years {
+		if year.Year != expected[i].year || year.Salary != expected[i].salary ||
+			year.Stock != expected[i].stock || year.SignOn != expected[i].signOn {
+			t.Fatalf("Expected %+v for year %d, got %+v", expected[i], i, year)
+		}
+		bonus, _ := year.Salary.Percentage(10 * types.PercentagePoint)
+		employer, _ := year.Salary.Percentage(5 * types.PercentagePoint)
+		if year.Bonus != bonus || year.Retirement.Employer != employer || year.Retirement.PensionAdjustment != employer {
+			t.Fatalf("Unexpected bonus %s or employer contribution %s for %+v", year.Bonus, year.Retirement.Employer, year)
+		}
+		if year.Gross() != year.Taxable()+year.Retirement.Employer+year.Benefits {
+			t.Fatalf("Unexpected gross %s for %+v", year.Gross(), year)
+		}
+		expectedTax, err := tax.Calculate(types.Ontario, year.Year, tax.Income{Employment: year.Taxable()})
+		if err != nil || expectedTax != year.Tax {
+			t.Fatalf("Expected tax %+v for %+v, got %+v, %v", expectedTax, year, year.Tax, err)
+		}
+		if year.AfterTax() != year.Gross()-year.Tax.IncomeTax()-year.Tax.Contributions() {
+			t.Fatalf("Unexpected after-tax %s for %+v", year.AfterTax(), year)
+		}
+	}
+	gross, afterTax := compensation.Total(years)
+	if gross <= afterTax || afterTax <= 0 {
+		t.Fatalf("Unexpected totals %s and %s", gross, afterTax)
+	}
+
+	// Errors
+	if _, err := offer.Project(compensation.Projection{Province: types.Ontario, Start: 2024}); err != compensation.ErrorInvalidHorizon {
+		t.Fatalf("Expected ErrorInvalidHorizon, got %v", err)
+	}
+	// Without a start date the sign-on bonus is paid from the start of the projection
+	late := compensation.Package{SignOn: compensation.SignOnBonus{Installments: []compensation.Installment{{Month: 0, Amount: 20000 * types.CashDollar}}}}
+	years, err = late.Project(compensation.Projection{Province: types.Ontario, Start: 2024, Years: 1})
+	if err != nil || years[0].SignOn != 20000*types.CashDollar {
+		t.Fatalf("Expected a $20'000 sign-on in 2024, got %+v, %v", years, err)
+	}
+	late.SignOn.Start = time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := late.Project(compensation.Projection{Province: types.Ontario, Start: 2024, Years: 2}); err != compensation.ErrorSignOnOutside {
+		t.Fatalf("Expected ErrorSignOnOutside, got %v", err)
+	}
+	offer.Grants[0].VestYears = 0
+	if _, err := offer.Project(compensation.Projection{Province: types.Ontario, Start: 2024, Years: 1}); err != compensation.ErrorInvalidVesting {
+		t.Fatalf("Expected ErrorInvalidVesting, got %v", err)
+	}
+}
@@ -0,0 +1,143 @@
+package compensation
+
+import (
+	"errors"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+const (
+	// Factor applied to the benefit earned in a year for the pension adjustment
+	pensionAdjustmentFactor = 9
+	// Offset subtracted from the pension adjustment of defined benefit plans
+	pensionAdjustmentOffset = 600 * types.CashDollar
+)
+
+var (
+	ErrorInvalidPension    = errors.New("a defined benefit pension must be paid for at least one year")
+	ErrorInvalidPensionAge = errors.New("the age must be positive and not past the retirement age")
+)
+
+// A defined benefit pension plan
+type DefinedBenefit struct {
+	AccrualRate   types.Percentage // Yearly pension per year of service, as a percentage of the best-average salary
+	BestYears     int              // Number of consecutive years averaged for the best-average salary
+	EmployeeRate  types.Percentage // Employee contributions, as a percentage of the salary
+	Indexation    types.Percentage // Yearly increase of the pension once in payment
+	Discount      types.Percentage // Yearly rate used to discount future payments
+	RetirementAge int              // Age at which the pension starts
+	PaymentYears  int              // Expected number of years the pension is paid
+}
+
+// The valuation of a pension accrued over some years of service
+type PensionValuation struct {
+	BestAverage  types.Cash // Best-average salary
+	Pension      types.Cash // Yearly pension earned, payable at retirement
+	PresentValue types.Cash // Present value of the pension
+	PerYear      types.Cash // Present value per year of service
+}
+
+// The pension earned in a single year of service
+type PensionAccrual struct {
+	Contribution      types.Cash // Employee contributions, deducted from income
+	Value             types.Cash // Present value of the pension earned
+	PensionAdjustment types.Cash // Reduction of the RRSP room of the next year
+}
+
+// Returns the present value of the pension earned by the employer contributions,
+// which can be negative when the employee contributions are worth more than the pension
+func (a PensionAccrual) Employer() types.Cash {
+	return a.Value - a.Contribution
+}
+
+// Returns the best average of consecutive salaries
+func (d DefinedBenefit) BestAverage(salaries []types.Cash) types.Cash {
+	if len(salaries) == 0 {
+		return 0
+	}
+	years := d.BestYears
+	if years <= 0 || years > len(salaries) {
+		years = len(salaries)
+	}
+	best := types.Cash(0)
+	for start := 0; start+years <= len(salaries); start++ {
+		total := types.Cash(0)
+		for _, salary := range salaries[start : start+years] {
+			total += salary
+		}
+		if average := total / types.Cash(years); average > best {
+			best = average
+		}
+	}
+	return best
+}
+
+// Values the pension accrued over the given years of service, given the salaries
+// of each year so far and the current age. The pension is paid yearly from the
+// retirement age, increased by the indexation and discounted back to today.
+// The age must be positive and at most the retirement age.
+func (d DefinedBenefit) Value(salaries []types.Cash, service int, age int) (PensionValuation, error) {
+	if d.PaymentYears <= 0 {
+		return PensionValuation{}, ErrorInvalidPension
+	}
+	if age <= 0 || d.RetirementAge < age {
+		return PensionValuation{}, ErrorInvalidPensionAge
+	}
+	valuation := PensionValuation{BestAverage: d.BestAverage(salaries)}
+	if service <= 0 {
+		return valuation, nil
+	}
+	yearly, err := valuation.BestAverage.Percentage(d.AccrualRate)
+	if err != nil {
+		return PensionValuation{}, err
+	}
+	valuation.Pension = yearly * types.Cash(service)
+	// Value at retirement of the indexed payments
+	payment := valuation.Pension
+	for i := 0; i < d.PaymentYears; i++ {
+		valuation.PresentValue += payment
+		if payment, err = d.grow(payment, d.Indexation); err != nil {
+			return PensionValuation{}, err
+		}
+	}
+	// Discount to today
+	for i := age; i < d.RetirementAge; i++ {
+		if valuation.PresentValue, err = d.grow(valuation.PresentValue, 0); err != nil {
+			return PensionValuation{}, err
+		}
+	}
+	valuation.PerYear = valuation.PresentValue / types.Cash(service)
+	return valuation, nil
+}
+
+// Returns the amount increased by the rate and discounted by a year
+func (d DefinedBenefit) grow(amount types.Cash, rate types.Percentage) (types.Cash, error) {
+	return amount.MulDiv(types.Cash(100*types.PercentagePoint+rate), types.Cash(100*types.PercentagePoint+d.Discount))
+}
+
+// Returns the pension earned in a year of service, given the salaries of each year so far.
+// The value earned includes the increase of the past service when the best-average salary grows.
+func (d DefinedBenefit) accrual(salaries []types.Cash, age int) (PensionAccrual, error) {
+	if d.AccrualRate == 0 || len(salaries) == 0 {
+		return PensionAccrual{}, nil
+	}
+	service := len(salaries)
+	current, err := d.Value(salaries, service, age)
+	if err != nil {
+		return PensionAccrual{}, err
+	}
+	previous, err := d.Value(salaries[:service-1], service-1, age)
+	if err != nil {
+		return PensionAccrual{}, err
+	}
+	accrual := PensionAccrual{Value: current.PresentValue - previous.PresentValue}
+	if accrual.Contribution, err = salaries[service-1].Percentage(d.EmployeeRate); err != nil {
+		return PensionAccrual{}, err
+	}
+	benefit, err := salaries[service-1].Percentage(d.AccrualRate)
+	if err != nil {
+		return PensionAccrual{}, err
+	}
+	accrual.PensionAdjustment = types.MaxCash(0, pensionAdjustmentFactor*benefit-pensionAdjustmentOffset)
+	return accrual, nil
+}
@@ -0,0 +1,148 @@
+package compensation_test
+
+import (
+	"testing"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/compensation"
+	"github.com/stefanovazzocell/SalaryAdvisor/tax"
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+func TestBestAverage(t *testing.T) {
+	salaries := []types.Cash{
+		50000 * types.CashDollar,
+		60000 * types.CashDollar,
+		70000 * types.CashDollar,
+		65000 * types.CashDollar,
+		80000 * types.CashDollar,
+	}
+	testCases := map[int]types.Cash{
+		0:  65000 * types.CashDollar,
+		1:  80000 * types.CashDollar,
+		3:  716666666,
+		5:  65000 * types.CashDollar,
+		10: 65000 * types.CashDollar,
+	}
+
+	for bestYears, expected := range testCases {
+		pension := compensation.DefinedBenefit{BestYears: bestYears}
+		if average := pension.BestAverage(salaries); average != expected {
+			t.Fatalf("Expected %s for %d years, got %s", expected, bestYears, average)
+		}
+	}
+}
+
+func TestDefinedBenefitValue(t *testing.T) {
+	salaries := []types.Cash{100000 * types.CashDollar}
+	testCases := map[struct {
+		pension compensation.DefinedBenefit
+		service int
+		age     int
+	}]compensation.PensionValuation{
+		// Without discount, the value is the sum of the payments
+		{compensation.DefinedBenefit{AccrualRate: 2 * types.PercentagePoint, RetirementAge: 65, PaymentYears: 20}, 10, 40}: {
+			BestAverage: 100000 * types.CashDollar, Pension: 20000 * types.CashDollar,
+			PresentValue: 400000 * types.CashDollar, PerYear: 40000 * types.CashDollar,
+		},
+		// Payments are discounted back to today
+		{compensation.DefinedBenefit{AccrualRate: 2 * types.PercentagePoint, Discount: 5 * types.PercentagePoint, RetirementAge: 42, PaymentYears: 1}, 1, 40}: {
+			BestAverage: 100000 * types.CashDollar, Pension: 2000 * types.CashDollar,
+			PresentValue: 18140589, PerYear: 18140589,
+		},
+		// Indexation offsets the discount
+		{compensation.DefinedBenefit{AccrualRate: 2 * types.PercentagePoint, Indexation: 2 * types.PercentagePoint, Discount: 2 * types.PercentagePoint, RetirementAge: 65, PaymentYears: 3}, 1, 65}: {
+			BestAverage: 100000 * types.CashDollar, Pension: 2000 * types.CashDollar,
+			PresentValue: 6000 * types.CashDollar, PerYear: 6000 * types.CashDollar,
+		},
+		{compensation.DefinedBenefit{AccrualRate: 2 * types.PercentagePoint, RetirementAge: 65, PaymentYears: 3}, 0, 40}: {
+			BestAverage: 100000 * types.CashDollar,
+		},
+	}
+
+	for testParams, expected := range testCases {
+		valuation, err := testParams.pension.Value(salaries, testParams.service, testParams.age)
+		if err != nil {
+			t.Fatalf("Unexpected error for %+v: %v", testParams, err)
+		}
+		if valuation != expected {
+			t.Fatalf("Expected %+v for %+v, got %+v", expected, testParams, valuation)
+		}
+	}
+
+	if _, err := (compensation.DefinedBenefit{}).Value(salaries, 1, 40); err != compensation.ErrorInvalidPension {
+		t.Fatalf("Expected %v, got %v", compensation.ErrorInvalidPension, err)
+	}
+	for _, age := range []int{0, 66} {
+		pension := compensation.DefinedBenefit{RetirementAge: 65, PaymentYears: 20}
+		if _, err := pension.Value(salaries, 1, age); err != compensation.ErrorInvalidPensionAge {
+			t.Fatalf("Expected %v at %d, got %v", compensation.ErrorInvalidPensionAge, age, err)
+		}
+	}
+	if _, err := (compensation.DefinedBenefit{PaymentYears: 20}).Value(salaries, 1, 40); err != compensation.ErrorInvalidPensionAge {
+		t.Fatalf("Expected %v without a retirement age, got %v", compensation.ErrorInvalidPensionAge, err)
+	}
+}
+
+func TestPackagePension(t *testing.T) {
+	pkg := compensation.Package{
+		BaseSalary: 100000 * types.CashDollar,
+		Pension: compensation.DefinedBenefit{
+			AccrualRate:   2 * types.PercentagePoint,
+			EmployeeRate:  9 * types.PercentagePoint,
+			RetirementAge: 65,
+			PaymentYears:  20,
+		},
+	}
+	years, err := pkg.Project(compensation.Projection{Province: types.Ontario, Start: 2024, Years: 1, Age: 40})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := compensation.PensionAccrual{
+		Contribution:      9000 * types.CashDollar,
+		Value:             40000 * types.CashDollar,
+		PensionAdjustment: 17400 * types.CashDollar,
+	}
+	if years[0].Pension != expected {
+		t.Fatalf("Expected %+v, got %+v", expected, years[0].Pension)
+	}
+	if years[0].Gross() != 131000*types.CashDollar {
+		t.Fatalf("Expected a gross of $131'000, got %s", years[0].Gross())
+	}
+	// Employee contributions are deducted
+	expectedTax, err := tax.Calculate(types.Ontario, 2024, tax.Income{
+		Employment: 100000 * types.CashDollar,
+		Deductions: 9000 * types.CashDollar,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if years[0].Tax != expectedTax {
+		t.Fatalf("Expected tax %+v, got %+v", expectedTax, years[0].Tax)
+	}
+}
+
+func TestPackagePensionPastService(t *testing.T) {
+	pkg := compensation.Package{
+		BaseSalary:  100000 * types.CashDollar,
+		AnnualRaise: 10 * types.PercentagePoint,
+		Pension: compensation.DefinedBenefit{
+			AccrualRate:   2 * types.PercentagePoint,
+			BestYears:     1,
+			RetirementAge: 65,
+			PaymentYears:  1,
+		},
+	}
+	years, err := pkg.Project(compensation.Projection{Province: types.Ontario, Start: 2024, Years: 2, Age: 64})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// The raise lifts the first year of service from $2'000 to $2'200 of pension,
+	// on top of the $2'200 earned in the second year
+	if years[0].Pension.Value != 2000*types.CashDollar || years[1].Pension.Value != 2400*types.CashDollar {
+		t.Fatalf("Expected $2'000 then $2'400 of pension value, got %s and %s", years[0].Pension.Value, years[1].Pension.Value)
+	}
+	// The age is required to value the pension
+	if _, err := pkg.Project(compensation.Projection{Province: types.Ontario, Start: 2024, Years: 2}); err != compensation.ErrorInvalidPensionAge {
+		t.Fatalf("Expected %v without an age, got %v", compensation.ErrorInvalidPensionAge, err)
+	}
+}
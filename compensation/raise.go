@@ -0,0 +1,76 @@
+package compensation
+
+import (
+	"errors"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+const (
+	raiseMax = 1000000 * types.CashDollar // 1 million $, keeping the projected salaries clear of overflows
+)
+
+var (
+	ErrorUnreachableRaise = errors.New("no raise can match the competing offer")
+)
+
+// Returns the raise on the base salary needed for the current package to match the
+// total after-tax compensation of a competing offer, each with its own projection.
+// The raise is returned to the cent and as a percentage of the current base salary,
+// if the current package is already ahead no raise is needed.
+func RaiseToMatch(current Package, currentProjection Projection, competitor Package, competitorProjection Projection) (types.Cash, types.Percentage, error) {
+	competitorYears, err := competitor.Project(competitorProjection)
+	if err != nil {
+		return 0, 0, err
+	}
+	_, target := Total(competitorYears)
+	matches := func(raise types.Cash) (bool, error) {
+		raised := current
+		raised.BaseSalary += raise
+		years, err := raised.Project(currentProjection)
+		if err != nil {
+			return false, err
+		}
+		_, afterTax := Total(years)
+		return afterTax >= target, nil
+	}
+	// Find an upper bound
+	low, high := types.Cash(0), 1000*types.CashDollar
+	if ok, err := matches(low); err != nil || ok {
+		return 0, 0, err
+	}
+	for {
+		ok, err := matches(high)
+		if err != nil {
+			return 0, 0, err
+		}
+		if ok {
+			break
+		}
+		if high >= raiseMax {
+			return 0, 0, ErrorUnreachableRaise
+		}
+		low, high = high, 2*high
+		if high > raiseMax {
+			high = raiseMax
+		}
+	}
+	// Search for the lowest raise, to the cent
+	for high-low > types.CashCent {
+		middle := low + (high-low)/2/types.CashCent*types.CashCent
+		ok, err := matches(middle)
+		if err != nil {
+			return 0, 0, err
+		}
+		if ok {
+			high = middle
+		} else {
+			low = middle
+		}
+	}
+	percentage, err := high.FractionOf(current.BaseSalary)
+	if err != nil {
+		return 0, 0, err
+	}
+	return high, percentage, nil
+}
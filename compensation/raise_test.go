@@ -0,0 +1,52 @@
+package compensation_test
+
+import (
+	"testing"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/compensation"
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+func TestRaiseToMatch(t *testing.T) {
+	current := compensation.Package{BaseSalary: 100000 * types.CashDollar, BonusTarget: 10 * types.PercentagePoint}
+	ontario := compensation.Projection{Province: types.Ontario, Start: 2024, Years: 3}
+	alberta := compensation.Projection{Province: types.Alberta, Start: 2024, Years: 3}
+
+	// Same province, the competitor pays 10% more
+	competitor := compensation.Package{BaseSalary: 110000 * types.CashDollar, BonusTarget: 10 * types.PercentagePoint}
+	raise, percentage, err := compensation.RaiseToMatch(current, ontario, competitor, ontario)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if raise < 9999*types.CashDollar || raise > 10000*types.CashDollar || percentage < 9999*types.PercentagePoint/1000 {
+		t.Fatalf("Expected a raise of about 10'000 (10%%), got %s (%s%%)", raise, percentage)
+	}
+
+	// Same package, but the competitor is in a province with lower taxes at this income
+	raise, percentage, err = compensation.RaiseToMatch(current, alberta, current, ontario)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if raise <= 0 || raise%types.CashCent != 0 || percentage <= 0 || percentage > 5*types.PercentagePoint {
+		t.Fatalf("Expected a small raise, got %s (%s%%)", raise, percentage)
+	}
+	raised := current
+	raised.BaseSalary += raise
+	raisedYears, _ := raised.Project(alberta)
+	competitorYears, _ := current.Project(ontario)
+	_, raisedTotal := compensation.Total(raisedYears)
+	_, competitorTotal := compensation.Total(competitorYears)
+	if raisedTotal < competitorTotal {
+		t.Fatalf("Expected %s to match %s", raisedTotal, competitorTotal)
+	}
+
+	// Already ahead
+	if raise, percentage, err := compensation.RaiseToMatch(competitor, ontario, current, ontario); raise != 0 || percentage != 0 || err != nil {
+		t.Fatalf("Expected no raise, got %s (%s%%), %v", raise, percentage, err)
+	}
+	// Unreachable
+	huge := compensation.Package{BaseSalary: 2000000 * types.CashDollar}
+	if _, _, err := compensation.RaiseToMatch(current, ontario, huge, ontario); err != compensation.ErrorUnreachableRaise {
+		t.Fatalf("Expected ErrorUnreachableRaise, got %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package compensation
+
+import (
+	"errors"
+	"time"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+const (
+	workDaysPerWeek = 5
+)
+
+var (
+	ErrorNoWorkingTime = errors.New("the schedule must include some working time")
+
+	// Number of general holidays observed by provincially regulated employers
+	statutoryHolidays = map[types.Province]int{
+		types.Alberta:                 9,
+		types.BritishColumbia:         11,
+		types.Manitoba:                9,
+		types.NewBrunswick:            8,
+		types.NewfoundlandAndLabrador: 6,
+		types.NorthwestTerritories:    11,
+		types.NovaScotia:              6,
+		types.Nunavut:                 10,
+		types.Ontario:                 9,
+		types.PrinceEdwardIsland:      8,
+		types.Quebec:                  8,
+		types.Saskatchewan:            10,
+		types.Yukon:                   11,
+		types.TestingProvince:         0,
+	}
+)
+
+// A work schedule, used to convert between hourly, daily and annual rates.
+// Working days are Monday to Friday, minus holidays, vacation and bench time.
+type Schedule struct {
+	Province      types.Province
+	Year          types.Year
+	HoursPerWeek  time.Duration // Ex: 37h30m
+	VacationWeeks int           // Weeks of vacation, paid or not
+	BenchDays     int           // Unpaid working days between contracts
+}
+
+// Returns the number of statutory holidays in the province of the schedule
+func (s Schedule) Holidays() int {
+	return statutoryHolidays[s.Province]
+}
+
+// Returns the number of days worked in the year
+func (s Schedule) WorkingDays() int {
+	days := 365
+	if s.Year.IsLeap() {
+		days = 366
+	}
+	// 52 full weeks, plus the remaining days
+	weekdays := 52 * workDaysPerWeek
+	start := time.Date(int(s.Year), time.January, 1, 0, 0, 0, 0, time.UTC).Weekday()
+	for i := 0; i < days-52*7; i++ {
+		day := (start + time.Weekday(i)) % 7
+		if day != time.Saturday && day != time.Sunday {
+			weekdays++
+		}
+	}
+	workingDays := weekdays - s.Holidays() - s.VacationWeeks*workDaysPerWeek - s.BenchDays
+	if workingDays < 0 {
+		return 0
+	}
+	return workingDays
+}
+
+// Returns the time worked in a day
+func (s Schedule) HoursPerDay() time.Duration {
+	return s.HoursPerWeek / workDaysPerWeek
+}
+
+// Converts an hourly rate into a daily rate
+func (s Schedule) DailyFromHourly(hourly types.Cash) (types.Cash, error) {
+	minutes := types.Cash(s.HoursPerDay() / time.Minute)
+	if minutes <= 0 {
+		return 0, ErrorNoWorkingTime
+	}
+	return hourly.MulDiv(minutes, 60)
+}
+
+// Converts a daily rate into an hourly rate
+func (s Schedule) HourlyFromDaily(daily types.Cash) (types.Cash, error) {
+	minutes := types.Cash(s.HoursPerDay() / time.Minute)
+	if minutes <= 0 {
+		return 0, ErrorNoWorkingTime
+	}
+	return daily.MulDiv(60, minutes)
+}
+
+// Converts a daily rate into the amount earned in the year
+func (s Schedule) AnnualFromDaily(daily types.Cash) (types.Cash, error) {
+	return daily.MulDiv(types.Cash(s.WorkingDays()), 1)
+}
+
+// Converts the amount earned in the year into a daily rate
+func (s Schedule) DailyFromAnnual(annual types.Cash) (types.Cash, error) {
+	days := types.Cash(s.WorkingDays())
+	if days <= 0 {
+		return 0, ErrorNoWorkingTime
+	}
+	return annual / days, nil
+}
+
+// Converts an hourly rate into the amount earned in the year
+func (s Schedule) AnnualFromHourly(hourly types.Cash) (types.Cash, error) {
+	daily, err := s.DailyFromHourly(hourly)
+	if err != nil {
+		return 0, err
+	}
+	return s.AnnualFromDaily(daily)
+}
+
+// Converts the amount earned in the year into an hourly rate
+func (s Schedule) HourlyFromAnnual(annual types.Cash) (types.Cash, error) {
+	minutes := types.Cash(s.HoursPerDay() / time.Minute)
+	days := types.Cash(s.WorkingDays())
+	if minutes <= 0 || days <= 0 {
+		return 0, ErrorNoWorkingTime
+	}
+	return annual.MulDiv(60, minutes*days)
+}
@@ -0,0 +1,74 @@
+package compensation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/compensation"
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+func TestScheduleWorkingDays(t *testing.T) {
+	testCases := map[compensation.Schedule]int{
+		{Province: types.TestingProvince, Year: 2023}:                          260, // Starts on a Sunday
+		{Province: types.TestingProvince, Year: 2024}:                          262, // Leap year starting on a Monday
+		{Province: types.TestingProvince, Year: 2028}:                          260, // Leap year starting on a Saturday
+		{Province: types.Ontario, Year: 2024}:                                  253,
+		{Province: types.NovaScotia, Year: 2024}:                               256,
+		{Province: types.Ontario, Year: 2024, VacationWeeks: 3}:                238,
+		{Province: types.Ontario, Year: 2024, VacationWeeks: 3, BenchDays: 20}: 218,
+		{Province: types.Ontario, Year: 2024, VacationWeeks: 52}:               0,
+	}
+
+	for schedule, expected := range testCases {
+		if days := schedule.WorkingDays(); days != expected {
+			t.Fatalf("Expected %d working days for %+v, got %d", expected, schedule, days)
+		}
+	}
+}
+
+func TestScheduleRates(t *testing.T) {
+	schedule := compensation.Schedule{
+		Province:      types.Ontario,
+		Year:          2024,
+		HoursPerWeek:  37*time.Hour + 30*time.Minute,
+		VacationWeeks: 3,
+	}
+	if hours := schedule.HoursPerDay(); hours != 7*time.Hour+30*time.Minute {
+		t.Fatalf("Expected 7h30m per day, got %v", hours)
+	}
+	hourly := 100 * types.CashDollar
+	daily, err := schedule.DailyFromHourly(hourly)
+	if err != nil || daily != 750*types.CashDollar {
+		t.Fatalf("Expected 750 per day, got %s, %v", daily, err)
+	}
+	annual, err := schedule.AnnualFromHourly(hourly)
+	if err != nil || annual != 178500*types.CashDollar {
+		t.Fatalf("Expected 178'500 per year, got %s, %v", annual, err)
+	}
+	if value, err := schedule.HourlyFromAnnual(annual); value != hourly || err != nil {
+		t.Fatalf("Expected 100 per hour, got %s, %v", value, err)
+	}
+	if value, err := schedule.DailyFromAnnual(annual); value != daily || err != nil {
+		t.Fatalf("Expected 750 per day, got %s, %v", value, err)
+	}
+	if value, err := schedule.HourlyFromDaily(daily); value != hourly || err != nil {
+		t.Fatalf("Expected 100 per hour, got %s, %v", value, err)
+	}
+	if value, err := schedule.AnnualFromDaily(daily); value != annual || err != nil {
+		t.Fatalf("Expected 178'500 per year, got %s, %v", value, err)
+	}
+
+	// Errors
+	empty := compensation.Schedule{Province: types.Ontario, Year: 2024}
+	if _, err := empty.HourlyFromAnnual(annual); err != compensation.ErrorNoWorkingTime {
+		t.Fatalf("Expected ErrorNoWorkingTime, got %v", err)
+	}
+	if _, err := empty.DailyFromHourly(hourly); err != compensation.ErrorNoWorkingTime {
+		t.Fatalf("Expected ErrorNoWorkingTime, got %v", err)
+	}
+	schedule.VacationWeeks = 52
+	if _, err := schedule.DailyFromAnnual(annual); err != compensation.ErrorNoWorkingTime {
+		t.Fatalf("Expected ErrorNoWorkingTime, got %v", err)
+	}
+}
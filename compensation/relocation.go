@@ -0,0 +1,48 @@
+package compensation
+
+import (
+	"errors"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+var (
+	ErrorNoProvinces = errors.New("at least one province is needed for a comparison")
+)
+
+// The outcome of a package for a resident of a province
+type ProvinceOutcome struct {
+	Province types.Province
+	OfferOutcome
+}
+
+// Compares the compensation of a resident of each province, for the whole projection.
+// The package can optionally be replaced for some provinces,
+// the first province is the baseline for differences and break-even points.
+func CompareProvinces(pkg Package, provinces []types.Province, projection Projection, packages map[types.Province]Package) ([]ProvinceOutcome, error) {
+	if len(provinces) == 0 {
+		return nil, ErrorNoProvinces
+	}
+	outcomes := make([]ProvinceOutcome, 0, len(provinces))
+	for _, province := range provinces {
+		provincePackage, ok := packages[province]
+		if !ok {
+			provincePackage = pkg
+		}
+		projection.Province = province
+		projection.Moves = nil
+		years, err := provincePackage.Project(projection)
+		if err != nil {
+			return nil, err
+		}
+		outcomes = append(outcomes, ProvinceOutcome{
+			Province:     province,
+			OfferOutcome: newOfferOutcome(province.String(), years),
+		})
+	}
+	baseline := outcomes[0].OfferOutcome
+	for i := range outcomes {
+		outcomes[i].compareWith(baseline, i == 0)
+	}
+	return outcomes, nil
+}
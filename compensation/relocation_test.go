@@ -0,0 +1,83 @@
+package compensation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/compensation"
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+func TestResidenceIn(t *testing.T) {
+	projection := compensation.Projection{
+		Province: types.Ontario,
+		Moves: []compensation.Move{
+			{To: types.Quebec, Date: time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)},
+			{To: types.Alberta, Date: time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC)},
+		},
+		Start: 2023,
+		Years: 4,
+	}
+	expected := map[types.Year]types.Province{
+		2023: types.Ontario,
+		2024: types.Alberta,
+		2025: types.Alberta,
+		2026: types.Quebec,
+	}
+	for year, province := range expected {
+		if residence := projection.ResidenceIn(year); residence != province {
+			t.Fatalf("Expected %s in %d, got %s", province, year, residence)
+		}
+	}
+	years, err := compensation.Package{BaseSalary: 100000 * types.CashDollar}.Project(projection)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, year := range years {
+		if year.Tax.Province != expected[year.Year] {
+			t.Fatalf("Expected %d to be taxed in %s, got %s", year.Year, expected[year.Year], year.Tax.Province)
+		}
+	}
+}
+
+func TestCompareProvinces(t *testing.T) {
+	pkg := compensation.Package{BaseSalary: 120000 * types.CashDollar}
+	projection := compensation.Projection{Province: types.Yukon, Start: 2024, Years: 2}
+	provinces := []types.Province{types.Ontario, types.Alberta, types.Quebec}
+	outcomes, err := compensation.CompareProvinces(pkg, provinces, projection, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(outcomes) != 3 {
+		t.Fatalf("Expected 3 outcomes, got %d", len(outcomes))
+	}
+	for i, outcome := range outcomes {
+		if outcome.Province != provinces[i] || outcome.Name != provinces[i].String() {
+			t.Fatalf("Expected %s, got %s (%q)", provinces[i], outcome.Province, outcome.Name)
+		}
+		for _, year := range outcome.Years {
+			if year.Tax.Province != provinces[i] {
+				t.Fatalf("Expected %s to be taxed in %s, got %s", outcome.Name, provinces[i], year.Tax.Province)
+			}
+		}
+	}
+	// Alberta takes less tax than Ontario, Quebec takes more
+	if outcomes[0].Diff[0] != 0 || outcomes[1].Diff[0] <= 0 || outcomes[2].Diff[0] >= 0 {
+		t.Fatalf("Unexpected differences %s, %s, %s", outcomes[0].Diff[0], outcomes[1].Diff[0], outcomes[2].Diff[0])
+	}
+
+	// A higher salary in Quebec makes up for the difference
+	packages := map[types.Province]compensation.Package{
+		types.Quebec: {BaseSalary: 140000 * types.CashDollar},
+	}
+	outcomes, err = compensation.CompareProvinces(pkg, provinces, projection, packages)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if outcomes[2].Diff[0] <= 0 || outcomes[2].BreakEven != 2024 {
+		t.Fatalf("Expected Quebec to be ahead, got %s from %d", outcomes[2].Diff[0], outcomes[2].BreakEven)
+	}
+	if _, err := compensation.CompareProvinces(pkg, nil, projection, nil); err != compensation.ErrorNoProvinces {
+		t.Fatalf("Expected ErrorNoProvinces, got %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+package compensation
+
+import (
+	"errors"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+var (
+	ErrorUnknownPlan = errors.New("unknown kind of retirement plan")
+)
+
+// The kind of an employer retirement plan
+type PlanKind uint8
+
+const (
+	GroupRRSP PlanKind = iota // Group registered retirement savings plan
+	DCPP                      // Defined contribution pension plan
+	DPSP                      // Deferred profit sharing plan, with employee contributions going to a group RRSP
+)
+
+// An employer retirement savings plan, with rates on the base salary.
+// For example, matching 50% of employee contributions up to 6% of the base salary
+// is a MatchRate of 50% and a MatchCap of 6%.
+type RetirementPlan struct {
+	Kind         PlanKind
+	EmployeeRate types.Percentage // Employee contributions
+	MatchRate    types.Percentage // Employer contributions per dollar of matched employee contributions
+	MatchCap     types.Percentage // Maximum employee contributions matched
+	EmployerBase types.Percentage // Employer contributions regardless of the employee ones
+}
+
+// The contributions to a retirement plan for a year, and their tax treatment
+type RetirementContributions struct {
+	Employee          types.Cash // Employee contributions, out of the salary
+	Employer          types.Cash // Employer contributions
+	TaxableBenefit    types.Cash // Employer contributions included in employment income
+	Deduction         types.Cash // Contributions deducted from income
+	PensionAdjustment types.Cash // Reduction of the RRSP room of the next year
+	RRSPRoomUsed      types.Cash // RRSP room used by contributions to a group RRSP
+}
+
+// Returns the contributions for the year given the base salary.
+// Employer contributions to a group RRSP are a taxable benefit offset by the deduction,
+// while those to a pension or profit sharing plan are not taxed but create a pension adjustment.
+func (r RetirementPlan) Contributions(salary types.Cash) (RetirementContributions, error) {
+	c := RetirementContributions{}
+	var err error
+	if c.Employee, err = salary.Percentage(r.EmployeeRate); err != nil {
+		return RetirementContributions{}, err
+	}
+	matched := r.EmployeeRate
+	if matched > r.MatchCap {
+		matched = r.MatchCap
+	}
+	matchedCash, err := salary.Percentage(matched)
+	if err != nil {
+		return RetirementContributions{}, err
+	}
+	match, err := matchedCash.Percentage(r.MatchRate)
+	if err != nil {
+		return RetirementContributions{}, err
+	}
+	base, err := salary.Percentage(r.EmployerBase)
+	if err != nil {
+		return RetirementContributions{}, err
+	}
+	c.Employer = match + base
+	switch r.Kind {
+	case GroupRRSP:
+		c.TaxableBenefit = c.Employer
+		c.Deduction = c.Employee + c.Employer
+		c.RRSPRoomUsed = c.Employee + c.Employer
+	case DCPP:
+		c.Deduction = c.Employee
+		c.PensionAdjustment = c.Employee + c.Employer
+	case DPSP:
+		c.Deduction = c.Employee
+		c.PensionAdjustment = c.Employer
+		c.RRSPRoomUsed = c.Employee
+	default:
+		return RetirementContributions{}, ErrorUnknownPlan
+	}
+	return c, nil
+}
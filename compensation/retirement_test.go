@@ -0,0 +1,78 @@
+package compensation_test
+
+import (
+	"testing"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/compensation"
+	"github.com/stefanovazzocell/SalaryAdvisor/tax"
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+func TestRetirementContributions(t *testing.T) {
+	testCases := map[compensation.RetirementPlan]compensation.RetirementContributions{
+		{Kind: compensation.GroupRRSP, EmployeeRate: 6 * types.PercentagePoint, MatchRate: 50 * types.PercentagePoint, MatchCap: 6 * types.PercentagePoint}: {
+			Employee: 6000 * types.CashDollar, Employer: 3000 * types.CashDollar, TaxableBenefit: 3000 * types.CashDollar,
+			Deduction: 9000 * types.CashDollar, RRSPRoomUsed: 9000 * types.CashDollar,
+		},
+		{Kind: compensation.DCPP, EmployeeRate: 8 * types.PercentagePoint, MatchRate: 100 * types.PercentagePoint, MatchCap: 5 * types.PercentagePoint, EmployerBase: 2 * types.PercentagePoint}: {
+			Employee: 8000 * types.CashDollar, Employer: 7000 * types.CashDollar,
+			Deduction: 8000 * types.CashDollar, PensionAdjustment: 15000 * types.CashDollar,
+		},
+		{Kind: compensation.DPSP, EmployeeRate: 4 * types.PercentagePoint, MatchRate: 100 * types.PercentagePoint, MatchCap: 6 * types.PercentagePoint}: {
+			Employee: 4000 * types.CashDollar, Employer: 4000 * types.CashDollar,
+			Deduction: 4000 * types.CashDollar, PensionAdjustment: 4000 * types.CashDollar, RRSPRoomUsed: 4000 * types.CashDollar,
+		},
+		{Kind: compensation.DCPP}: {},
+	}
+
+	for plan, expected := range testCases {
+		contributions, err := plan.Contributions(100000 * types.CashDollar)
+		if err != nil {
+			t.Fatalf("Unexpected error for %+v: %v", plan, err)
+		}
+		if contributions != expected {
+			t.Fatalf("Expected %+v for %+v, got %+v", expected, plan, contributions)
+		}
+	}
+
+	if _, err := (compensation.RetirementPlan{Kind: 42}).Contributions(0); err != compensation.ErrorUnknownPlan {
+		t.Fatalf("Expected %v, got %v", compensation.ErrorUnknownPlan, err)
+	}
+}
+
+func TestPackageRetirement(t *testing.T) {
+	pkg := compensation.Package{
+		BaseSalary: 100000 * types.CashDollar,
+		Retirement: compensation.RetirementPlan{
+			Kind:         compensation.GroupRRSP,
+			EmployeeRate: 6 * types.PercentagePoint,
+			MatchRate:    50 * types.PercentagePoint,
+			MatchCap:     6 * types.PercentagePoint,
+		},
+	}
+	projection := compensation.Projection{Province: types.Ontario, Start: 2024, Years: 1}
+	years, err := pkg.Project(projection)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// Employer contributions to a group RRSP are taxable, and all contributions are deducted
+	expectedTax, err := tax.Calculate(types.Ontario, 2024, tax.Income{
+		Employment: 103000 * types.CashDollar,
+		Deductions: 9000 * types.CashDollar,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if years[0].Tax != expectedTax || years[0].Gross() != 103000*types.CashDollar {
+		t.Fatalf("Expected tax %+v on $103'000, got %+v on %s", expectedTax, years[0].Tax, years[0].Gross())
+	}
+	// The match adds to the value of the package
+	pkg.Retirement = compensation.RetirementPlan{}
+	unmatched, err := pkg.Project(projection)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if years[0].AfterTax() <= unmatched[0].AfterTax() {
+		t.Fatalf("Expected more than %s after tax with the match, got %s", unmatched[0].AfterTax(), years[0].AfterTax())
+	}
+}
@@ -0,0 +1,83 @@
+package compensation
+
+import (
+	"errors"
+	"time"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+var (
+	ErrorInvalidMonths = errors.New("the number of months must not be negative")
+)
+
+// A single payment of a sign-on bonus
+type Installment struct {
+	Month  int        // Months after the start date when the installment is paid
+	Amount types.Cash // Amount paid
+}
+
+// SignOnBonus represents a sign-on bonus paid in installments,
+// which has to be repaid when leaving within the clawback period
+type SignOnBonus struct {
+	Start          time.Time // Employment start date
+	Installments   []Installment
+	ClawbackMonths int  // Months after the start during which leaving triggers a repayment
+	Prorated       bool // If true, only the portion for the months left in the clawback period is repaid
+}
+
+// The outcome of a sign-on bonus given how long the employee stays
+type SignOnOutcome struct {
+	Paid   types.Cash                // Total amount received
+	Repaid types.Cash                // Amount repaid because of the clawback
+	ByYear map[types.Year]types.Cash // Taxable amount per year, repayments are deducted in the year they happen
+}
+
+// Returns the net value of the bonus
+func (o SignOnOutcome) Net() types.Cash {
+	return o.Paid - o.Repaid
+}
+
+// Returns the total amount of the bonus if the employee stays long enough
+func (b SignOnBonus) Total() types.Cash {
+	total := types.Cash(0)
+	for _, installment := range b.Installments {
+		total += installment.Amount
+	}
+	return total
+}
+
+// Computes the outcome of the bonus if the employee leaves after the given number of months.
+// Installments scheduled on or after the departure are not paid.
+func (b SignOnBonus) Stay(months int) (SignOnOutcome, error) {
+	if months < 0 {
+		return SignOnOutcome{}, ErrorInvalidMonths
+	}
+	outcome := SignOnOutcome{
+		ByYear: map[types.Year]types.Cash{},
+	}
+	// Collect the installments
+	for _, installment := range b.Installments {
+		if installment.Month >= months {
+			continue
+		}
+		year := types.Year(b.Start.AddDate(0, installment.Month, 0).Year())
+		outcome.Paid += installment.Amount
+		outcome.ByYear[year] += installment.Amount
+	}
+	// Apply the clawback
+	if months >= b.ClawbackMonths || outcome.Paid == 0 {
+		return outcome, nil
+	}
+	outcome.Repaid = outcome.Paid
+	if b.Prorated {
+		repaid, err := outcome.Paid.MulDiv(types.Cash(b.ClawbackMonths-months), types.Cash(b.ClawbackMonths))
+		if err != nil {
+			return SignOnOutcome{}, err
+		}
+		outcome.Repaid = repaid
+	}
+	leaveYear := types.Year(b.Start.AddDate(0, months, 0).Year())
+	outcome.ByYear[leaveYear] -= outcome.Repaid
+	return outcome, nil
+}
@@ -0,0 +1,66 @@
+package compensation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/compensation"
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+func TestSignOnBonus(t *testing.T) {
+	bonus := compensation.SignOnBonus{
+		Start: time.Date(2024, time.September, 1, 0, 0, 0, 0, time.UTC),
+		Installments: []compensation.Installment{
+			{Month: 0, Amount: 20000 * types.CashDollar},
+			{Month: 12, Amount: 10000 * types.CashDollar},
+		},
+		ClawbackMonths: 24,
+		Prorated:       true,
+	}
+	if total := bonus.Total(); total != 30000*types.CashDollar {
+		t.Fatalf("Expected a total of 30'000, got %s", total)
+	}
+	testCases := map[int]struct {
+		paid   types.Cash
+		repaid types.Cash
+		byYear map[types.Year]types.Cash
+	}{
+		0:  {0, 0, map[types.Year]types.Cash{}},
+		6:  {20000 * types.CashDollar, 15000 * types.CashDollar, map[types.Year]types.Cash{2024: 20000 * types.CashDollar, 2025: -15000 * types.CashDollar}},
+		12: {20000 * types.CashDollar, 10000 * types.CashDollar, map[types.Year]types.Cash{2024: 20000 * types.CashDollar, 2025: -10000 * types.CashDollar}},
+		18: {30000 * types.CashDollar, 7500 * types.CashDollar, map[types.Year]types.Cash{2024: 20000 * types.CashDollar, 2025: 10000 * types.CashDollar, 2026: -7500 * types.CashDollar}},
+		24: {30000 * types.CashDollar, 0, map[types.Year]types.Cash{2024: 20000 * types.CashDollar, 2025: 10000 * types.CashDollar}},
+	}
+
+	for months, expected := range testCases {
+		outcome, err := bonus.Stay(months)
+		if err != nil {
+			t.Fatalf("Unexpected error for %d months: %v", months, err)
+		}
+		if outcome.Paid != expected.paid || outcome.Repaid != expected.repaid {
+			t.Fatalf("Expected %s paid and %s repaid after %d months, got %s and %s",
+				expected.paid, expected.repaid, months, outcome.Paid, outcome.Repaid)
+		}
+		if outcome.Net() != expected.paid-expected.repaid {
+			t.Fatalf("Unexpected net %s after %d months", outcome.Net(), months)
+		}
+		if len(outcome.ByYear) != len(expected.byYear) {
+			t.Fatalf("Expected %v by year after %d months, got %v", expected.byYear, months, outcome.ByYear)
+		}
+		for year, amount := range expected.byYear {
+			if outcome.ByYear[year] != amount {
+				t.Fatalf("Expected %s in %d after %d months, got %s", amount, year, months, outcome.ByYear[year])
+			}
+		}
+	}
+
+	// Without proration the full amount received is repaid
+	bonus.Prorated = false
+	if outcome, err := bonus.Stay(18); outcome.Repaid != 30000*types.CashDollar || err != nil {
+		t.Fatalf("Expected full repayment, got %s, %v", outcome.Repaid, err)
+	}
+	if _, err := bonus.Stay(-1); err != compensation.ErrorInvalidMonths {
+		t.Fatalf("Expected ErrorInvalidMonths, got %v", err)
+	}
+}
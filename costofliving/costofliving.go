@@ -0,0 +1,170 @@
+package costofliving
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+var (
+	ErrorUnknownCity     = errors.New("no cost of living data is available for this city")
+	ErrorInvalidRecord   = errors.New("cost of living records must have a city, a province code and an index")
+	ErrorInvalidIndex    = errors.New("the cost of living index must be positive")
+	ErrorUnknownProvince = errors.New("unknown province code")
+
+	//go:embed cities.csv
+	defaultCSV     []byte
+	defaultDataset = mustLoad(defaultCSV)
+)
+
+// The cost of living in a city
+type City struct {
+	Name     string
+	Province types.Province
+	Index    types.Percentage // Cost of living relative to the national average (100%)
+}
+
+// Converts net pay in this city to the amount with the same purchasing power
+// at the national average cost of living
+func (c City) PurchasingPower(net types.Cash) (types.Cash, error) {
+	if c.Index <= 0 {
+		return 0, ErrorInvalidIndex
+	}
+	return net.MulDiv(types.Cash(100*types.PercentagePoint), types.Cash(c.Index))
+}
+
+// A set of cities with their cost of living
+type Dataset struct {
+	cities map[string]City
+}
+
+// Returns the embedded dataset
+func Default() Dataset {
+	return defaultDataset
+}
+
+// Loads a dataset from a CSV with a header and the columns:
+// city, province code, index (100 == national average).
+// Lines starting with '#' are ignored.
+func Load(r io.Reader) (Dataset, error) {
+	return Dataset{cities: map[string]City{}}.Merge(r)
+}
+
+// Returns a new dataset with the cities from the CSV added,
+// replacing the existing ones with the same name
+func (d Dataset) Merge(r io.Reader) (Dataset, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = 3
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return Dataset{}, err
+	}
+	merged := Dataset{cities: make(map[string]City, len(d.cities)+len(records))}
+	for key, city := range d.cities {
+		merged.cities[key] = city
+	}
+	for i, record := range records {
+		// Skip the header
+		if i == 0 {
+			continue
+		}
+		city, err := parseRecord(record)
+		if err != nil {
+			return Dataset{}, err
+		}
+		merged.cities[normalize(city.Name)] = city
+	}
+	return merged, nil
+}
+
+// Returns a city by name, case insensitive
+func (d Dataset) City(name string) (City, bool) {
+	city, ok := d.cities[normalize(name)]
+	return city, ok
+}
+
+// Returns all the cities, sorted by name
+func (d Dataset) Cities() []City {
+	cities := make([]City, 0, len(d.cities))
+	for _, city := range d.cities {
+		cities = append(cities, city)
+	}
+	sort.Slice(cities, func(i, j int) bool {
+		return cities[i].Name < cities[j].Name
+	})
+	return cities
+}
+
+// Returns the cities in a province, sorted by name
+func (d Dataset) CitiesIn(province types.Province) []City {
+	cities := []City{}
+	for _, city := range d.Cities() {
+		if city.Province == province {
+			cities = append(cities, city)
+		}
+	}
+	return cities
+}
+
+// Converts net pay in a city to the amount with the same purchasing power in another
+func (d Dataset) Equivalent(net types.Cash, from string, to string) (types.Cash, error) {
+	fromCity, ok := d.City(from)
+	if !ok {
+		return 0, ErrorUnknownCity
+	}
+	toCity, ok := d.City(to)
+	if !ok {
+		return 0, ErrorUnknownCity
+	}
+	if fromCity.Index <= 0 || toCity.Index <= 0 {
+		return 0, ErrorInvalidIndex
+	}
+	return net.MulDiv(types.Cash(toCity.Index), types.Cash(fromCity.Index))
+}
+
+// Parses a single CSV record
+func parseRecord(record []string) (City, error) {
+	name := strings.TrimSpace(record[0])
+	if name == "" {
+		return City{}, ErrorInvalidRecord
+	}
+	province, ok := types.ParseProvince(strings.TrimSpace(record[1]))
+	if !ok {
+		return City{}, ErrorUnknownProvince
+	}
+	index, err := types.ParsePercentage(record[2], true)
+	if err != nil {
+		return City{}, err
+	}
+	if index <= 0 {
+		return City{}, ErrorInvalidIndex
+	}
+	return City{
+		Name:     name,
+		Province: province,
+		Index:    index,
+	}, nil
+}
+
+// Returns the key used to look up a city
+func normalize(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// Loads a dataset, panics on failure.
+// Only meant to be used for the embedded data.
+func mustLoad(data []byte) Dataset {
+	dataset, err := Load(bytes.NewReader(data))
+	if err != nil {
+		panic(err)
+	}
+	return dataset
+}
@@ -0,0 +1,93 @@
+package costofliving_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/costofliving"
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+func TestDefault(t *testing.T) {
+	dataset := costofliving.Default()
+	toronto, ok := dataset.City(" toronto")
+	if !ok || toronto.Province != types.Ontario || toronto.Index != 116*types.PercentagePoint {
+		t.Fatalf("Unexpected Toronto data %+v, %v", toronto, ok)
+	}
+	if power, err := toronto.PurchasingPower(116000 * types.CashDollar); power != 100000*types.CashDollar || err != nil {
+		t.Fatalf("Expected 100'000 of purchasing power, got %s, %v", power, err)
+	}
+	// Every province has at least a city
+	for code := range map[string]bool{"ab": true, "bc": true, "mb": true, "nb": true, "nl": true, "nt": true, "ns": true,
+		"nu": true, "on": true, "pe": true, "qc": true, "sk": true, "yt": true} {
+		province, _ := types.ParseProvince(code)
+		if len(dataset.CitiesIn(province)) == 0 {
+			t.Fatalf("Expected at least a city in %s", province)
+		}
+	}
+}
+
+func TestEquivalent(t *testing.T) {
+	dataset := costofliving.Default()
+	testCases := map[struct {
+		from string
+		to   string
+	}]struct {
+		cash types.Cash
+		err  error
+	}{
+		{"Montreal", "Montreal"}: {100000 * types.CashDollar, nil},
+		{"Montreal", "Calgary"}:  {1041237113, nil},
+		{"Toronto", "Montreal"}:  {836206896, nil},
+		{"Atlantis", "Montreal"}: {0, costofliving.ErrorUnknownCity},
+		{"Montreal", "Atlantis"}: {0, costofliving.ErrorUnknownCity},
+	}
+
+	for testParams, testExpected := range testCases {
+		cash, err := dataset.Equivalent(100000*types.CashDollar, testParams.from, testParams.to)
+		if cash != testExpected.cash || err != testExpected.err {
+			t.Fatalf("Expected %d, %v for %v, got %d, %v", testExpected.cash, testExpected.err, testParams, cash, err)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	custom := "# My own data\ncity,province,index\nToronto,ON,120\nSudbury, on, 95.5\n"
+	dataset, err := costofliving.Default().Merge(strings.NewReader(custom))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if toronto, _ := dataset.City("Toronto"); toronto.Index != 120*types.PercentagePoint {
+		t.Fatalf("Expected Toronto to be overridden, got %+v", toronto)
+	}
+	if sudbury, ok := dataset.City("Sudbury"); !ok || sudbury.Index != 955*types.PercentagePoint/10 || sudbury.Province != types.Ontario {
+		t.Fatalf("Expected Sudbury to be added, got %+v, %v", sudbury, ok)
+	}
+	if len(dataset.Cities()) != len(costofliving.Default().Cities())+1 {
+		t.Fatalf("Expected a single city to be added")
+	}
+	if toronto, _ := costofliving.Default().City("Toronto"); toronto.Index != 116*types.PercentagePoint {
+		t.Fatalf("Expected the default dataset to be unchanged, got %+v", toronto)
+	}
+	// Only the custom data
+	dataset, err = costofliving.Load(strings.NewReader(custom))
+	if err != nil || len(dataset.Cities()) != 2 {
+		t.Fatalf("Expected 2 cities, got %d, %v", len(dataset.Cities()), err)
+	}
+
+	// Errors
+	testCases := map[string]error{
+		"city,province,index\nToronto,ZZ,100\n": costofliving.ErrorUnknownProvince,
+		"city,province,index\nToronto,ON,0\n":   costofliving.ErrorInvalidIndex,
+		"city,province,index\nToronto,ON,-5\n":  costofliving.ErrorInvalidIndex,
+		"city,province,index\n,ON,100\n":        costofliving.ErrorInvalidRecord,
+	}
+	for data, expectedErr := range testCases {
+		if _, err := costofliving.Load(strings.NewReader(data)); err != expectedErr {
+			t.Fatalf("Expected %v for %q, got %v", expectedErr, data, err)
+		}
+	}
+	if _, err := costofliving.Load(strings.NewReader("city,province,index\nToronto,ON\n")); err == nil {
+		t.Fatalf("Expected an error for missing columns")
+	}
+}
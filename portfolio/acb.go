@@ -0,0 +1,211 @@
+package portfolio
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+const (
+	// Portion of a capital gain that is included in income
+	InclusionRate = 50 * types.PercentagePoint
+	// Days before and after a disposition during which an acquisition
+	// of identical property makes a loss superficial
+	superficialLossDays = 30
+)
+
+var (
+	ErrorInvalidQuantity    = errors.New("the quantity of shares must be positive")
+	ErrorInsufficientShares = errors.New("cannot sell more shares than currently held")
+)
+
+// The kind of a portfolio event
+type EventKind uint8
+
+const (
+	Vest     EventKind = iota // Shares received on vest, acquired at fair market value
+	Purchase                  // Shares bought on the market or through a plan
+	Sale                      // Shares sold
+)
+
+// A portfolio event, amounts are in CAD
+type Event struct {
+	Kind     EventKind
+	Date     time.Time
+	Quantity types.Shares
+	Amount   types.Cash // Fair market value on vest, cost of purchase or proceeds of sale
+	Fees     types.Cash // Commissions and other outlays
+}
+
+// The disposition of shares through a sale
+type Disposition struct {
+	Date            time.Time
+	Quantity        types.Shares
+	Proceeds        types.Cash // Proceeds net of fees
+	ACB             types.Cash // Adjusted cost base of the shares sold
+	Gain            types.Cash // Capital gain, or loss if negative
+	SuperficialLoss bool       // True if the loss is (partially) denied as superficial
+	DeniedLoss      types.Cash // Portion of the loss denied and added to the cost base
+}
+
+// The outcome of a series of events
+type Report struct {
+	Dispositions []Disposition
+	Shares       types.Shares              // Shares held after the last event
+	ACB          types.Cash                // Total adjusted cost base of the shares held
+	Gains        map[types.Year]types.Cash // Net capital gains per year
+}
+
+// Tracks the adjusted cost base of identical shares
+type Tracker struct {
+	events []Event
+}
+
+// Records shares received on vest given the value of the vested shares
+func (t *Tracker) Vest(date time.Time, quantity types.Shares, value types.Stock) error {
+	return t.add(Vest, date, quantity, value, 0)
+}
+
+// Records shares bought given their total cost and fees
+func (t *Tracker) Buy(date time.Time, quantity types.Shares, cost types.Stock, fees types.Cash) error {
+	return t.add(Purchase, date, quantity, cost, fees)
+}
+
+// Records shares sold given their total proceeds and fees
+func (t *Tracker) Sell(date time.Time, quantity types.Shares, proceeds types.Stock, fees types.Cash) error {
+	return t.add(Sale, date, quantity, proceeds, fees)
+}
+
+// Returns the recorded events in chronological order
+func (t *Tracker) Events() []Event {
+	events := make([]Event, len(t.events))
+	copy(events, t.events)
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Date.Before(events[j].Date)
+	})
+	return events
+}
+
+// Records a new event
+func (t *Tracker) add(kind EventKind, date time.Time, quantity types.Shares, amount types.Stock, fees types.Cash) error {
+	if quantity <= 0 {
+		return ErrorInvalidQuantity
+	}
+	cad, _, _, err := amount.Value()
+	if err != nil {
+		return err
+	}
+	t.events = append(t.events, Event{
+		Kind:     kind,
+		Date:     date,
+		Quantity: quantity,
+		Amount:   cad,
+		Fees:     fees,
+	})
+	return nil
+}
+
+// Computes the dispositions, the resulting capital gains and
+// the adjusted cost base of the remaining shares.
+// Denied superficial losses are added to the cost base of
+// the pool at the time of the sale.
+func (t *Tracker) Report() (Report, error) {
+	events := t.Events()
+	report := Report{
+		Dispositions: []Disposition{},
+		Gains:        map[types.Year]types.Cash{},
+	}
+	for i, event := range events {
+		// Acquisitions
+		if event.Kind != Sale {
+			report.Shares += event.Quantity
+			report.ACB += event.Amount + event.Fees
+			continue
+		}
+		// Dispositions
+		if event.Quantity > report.Shares {
+			return Report{}, ErrorInsufficientShares
+		}
+		acb, err := report.ACB.MulDiv(types.Cash(event.Quantity), types.Cash(report.Shares))
+		if err != nil {
+			return Report{}, err
+		}
+		disposition := Disposition{
+			Date:     event.Date,
+			Quantity: event.Quantity,
+			Proceeds: event.Amount - event.Fees,
+			ACB:      acb,
+		}
+		disposition.Gain = disposition.Proceeds - acb
+		report.Shares -= event.Quantity
+		report.ACB -= acb
+		// Check for superficial losses
+		if disposition.Gain < 0 {
+			denied, err := deniedLoss(events, i, -disposition.Gain)
+			if err != nil {
+				return Report{}, err
+			}
+			if denied > 0 {
+				disposition.SuperficialLoss = true
+				disposition.DeniedLoss = denied
+				disposition.Gain += denied
+				report.ACB += denied
+			}
+		}
+		report.Dispositions = append(report.Dispositions, disposition)
+		report.Gains[types.Year(event.Date.Year())] += disposition.Gain
+	}
+	return report, nil
+}
+
+// Returns the portion of the loss of the sale at index i that is superficial.
+// The denied fraction is the least of the shares sold, the shares acquired
+// within 30 days before or after the sale and the shares held 30 days after
+// the sale, over the shares sold.
+func deniedLoss(events []Event, i int, loss types.Cash) (types.Cash, error) {
+	sale := events[i]
+	windowStart := sale.Date.AddDate(0, 0, -superficialLossDays)
+	windowEnd := sale.Date.AddDate(0, 0, superficialLossDays)
+	acquired := types.Shares(0)
+	held := types.Shares(0)
+	for _, event := range events {
+		if event.Date.After(windowEnd) {
+			break
+		}
+		if event.Kind == Sale {
+			held -= event.Quantity
+			continue
+		}
+		held += event.Quantity
+		if !event.Date.Before(windowStart) {
+			acquired += event.Quantity
+		}
+	}
+	denied := sale.Quantity
+	if acquired < denied {
+		denied = acquired
+	}
+	if held < denied {
+		denied = held
+	}
+	if denied <= 0 {
+		return 0, nil
+	}
+	return loss.MulDiv(types.Cash(denied), types.Cash(sale.Quantity))
+}
+
+// Returns the adjusted cost base of a single share held
+func (r Report) ACBPerShare() (types.Cash, error) {
+	if r.Shares <= 0 {
+		return 0, nil
+	}
+	return r.ACB.MulDiv(types.Cash(types.ShareUnit), types.Cash(r.Shares))
+}
+
+// Returns the taxable capital gain for the given year,
+// negative values are allowable capital losses
+func (r Report) TaxableGain(year types.Year) (types.Cash, error) {
+	return r.Gains[year].Percentage(InclusionRate)
+}
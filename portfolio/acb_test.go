@@ -0,0 +1,116 @@
+package portfolio_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/portfolio"
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func cad(dollars types.Cash) types.Stock {
+	return types.NewStock(dollars*types.CashDollar, 0)
+}
+
+func TestTracker(t *testing.T) {
+	tracker := portfolio.Tracker{}
+	// Recorded out of order on purpose
+	steps := []error{
+		tracker.Sell(date(2023, time.September, 1), 50*types.ShareUnit, cad(7000), 10*types.CashDollar),
+		tracker.Vest(date(2023, time.January, 15), 100*types.ShareUnit, types.NewStock(7500*types.CashDollar, 13333)),
+		tracker.Buy(date(2023, time.June, 1), 100*types.ShareUnit, cad(12000), 10*types.CashDollar),
+		tracker.Sell(date(2024, time.March, 1), 50*types.ShareUnit, cad(4000), 0),
+		tracker.Buy(date(2024, time.March, 15), 20*types.ShareUnit, cad(1500), 0),
+	}
+	for i, err := range steps {
+		if err != nil {
+			t.Fatalf("Unexpected error at step %d: %v", i, err)
+		}
+	}
+	report, err := tracker.Report()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(report.Dispositions) != 2 {
+		t.Fatalf("Expected 2 dispositions, got %d", len(report.Dispositions))
+	}
+	// Vested at 9'999.75 CAD, bought at 12'010 CAD
+	first := report.Dispositions[0]
+	if first.Proceeds != 6990*types.CashDollar || first.ACB != 55024375 || first.Gain != 14875625 || first.SuperficialLoss {
+		t.Fatalf("Unexpected first disposition %+v", first)
+	}
+	// 20 of the 50 shares sold at a loss are bought back within 30 days
+	second := report.Dispositions[1]
+	if !second.SuperficialLoss || second.ACB != 55024375 || second.DeniedLoss != 6009750 || second.Gain != -9014625 {
+		t.Fatalf("Unexpected second disposition %+v", second)
+	}
+	if report.Shares != 120*types.ShareUnit || report.ACB != 131058500 {
+		t.Fatalf("Expected 120 shares with ACB 13'105.85, got %s with %s", report.Shares, report.ACB)
+	}
+	if perShare, err := report.ACBPerShare(); perShare != 1092154 || err != nil {
+		t.Fatalf("Expected ACB per share of 109.22, got %s, %v", perShare, err)
+	}
+	for year, expected := range map[types.Year]types.Cash{2022: 0, 2023: 7437812, 2024: -4507312} {
+		if taxable, err := report.TaxableGain(year); taxable != expected || err != nil {
+			t.Fatalf("Expected taxable gain of %d in %d, got %d, %v", expected, year, taxable, err)
+		}
+	}
+}
+
+func TestTrackerSuperficialLoss(t *testing.T) {
+	testCases := map[time.Time]struct {
+		superficial bool
+		denied      types.Cash
+		gain        types.Cash
+	}{
+		date(2024, time.January, 1):  {false, 0, -6666666},                                    // Acquired too early
+		date(2024, time.February, 1): {true, 3333333, -3333333},                               // Acquired within the 30 days before
+		date(2024, time.March, 30):   {true, 500 * types.CashDollar, -500 * types.CashDollar}, // Acquired within the 30 days after
+		date(2024, time.April, 15):   {false, 0, -1000 * types.CashDollar},                    // Acquired too late
+	}
+
+	for repurchase, expected := range testCases {
+		tracker := portfolio.Tracker{}
+		steps := []error{
+			tracker.Buy(date(2023, time.January, 1), 10*types.ShareUnit, cad(2000), 0),
+			tracker.Sell(date(2024, time.March, 1), 10*types.ShareUnit, cad(1000), 0),
+			tracker.Buy(repurchase, 5*types.ShareUnit, cad(500), 0),
+		}
+		for i, err := range steps {
+			if err != nil {
+				t.Fatalf("Unexpected error at step %d for %v: %v", i, repurchase, err)
+			}
+		}
+		report, err := tracker.Report()
+		if err != nil {
+			t.Fatalf("Unexpected error for %v: %v", repurchase, err)
+		}
+		disposition := report.Dispositions[0]
+		if disposition.SuperficialLoss != expected.superficial || disposition.DeniedLoss != expected.denied {
+			t.Fatalf("Expected superficial %v with %s denied for %v, got %+v", expected.superficial, expected.denied, repurchase, disposition)
+		}
+		if disposition.Gain != expected.gain {
+			t.Fatalf("Unexpected gain %d for %v", disposition.Gain, repurchase)
+		}
+	}
+}
+
+func TestTrackerErrors(t *testing.T) {
+	tracker := portfolio.Tracker{}
+	if err := tracker.Buy(date(2024, time.January, 1), 0, cad(100), 0); err != portfolio.ErrorInvalidQuantity {
+		t.Fatalf("Expected ErrorInvalidQuantity, got %v", err)
+	}
+	if err := tracker.Buy(date(2024, time.January, 2), types.ShareUnit, cad(100), 0); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := tracker.Sell(date(2024, time.January, 1), types.ShareUnit, cad(100), 0); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := tracker.Report(); err != portfolio.ErrorInsufficientShares {
+		t.Fatalf("Expected ErrorInsufficientShares, got %v", err)
+	}
+}
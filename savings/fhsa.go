@@ -0,0 +1,144 @@
+package savings
+
+import (
+	"errors"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/tax"
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+const (
+	// Participation room added every year the account is open
+	fhsaAnnualLimit = 8000 * types.CashDollar
+	// Maximum contributions and transfers over the life of the account
+	fhsaLifetimeLimit = 40000 * types.CashDollar
+	// Maximum number of years the account can stay open
+	fhsaMaxYears = 15
+	// First year FHSAs could be opened
+	fhsaFirstYear = types.Year(2023)
+)
+
+var (
+	ErrorBeforeFHSA = errors.New("FHSAs were only introduced in 2023")
+)
+
+// The FHSA room and contributions of a year
+type FHSAYear struct {
+	Year          types.Year
+	Room          types.Cash // Participation room available for the year
+	Contributions types.Cash // Contributions, deductible this year or in a later one
+	Transfers     types.Cash // Transfers from an RRSP, not deductible
+	CarryForward  types.Cash // Unused room carried to the next year
+	Lifetime      types.Cash // Contributions and transfers counted towards the lifetime limit
+	Excess        types.Cash // Contributions and transfers above the room at the end of the year
+}
+
+// Tracks the participation room of a first home savings account
+type FHSA struct {
+	Opened        types.Year // Year the first FHSA was opened
+	contributions map[types.Year]types.Cash
+	transfers     map[types.Year]types.Cash
+}
+
+// Records a contribution in a year
+func (f *FHSA) Contribute(year types.Year, amount types.Cash) error {
+	if amount <= 0 {
+		return ErrorInvalidAmount
+	}
+	if f.contributions == nil {
+		f.contributions = map[types.Year]types.Cash{}
+	}
+	f.contributions[year] += amount
+	return nil
+}
+
+// Records a transfer from an RRSP in a year, which uses
+// FHSA room without restoring RRSP room
+func (f *FHSA) TransferFromRRSP(year types.Year, amount types.Cash) error {
+	if amount <= 0 {
+		return ErrorInvalidAmount
+	}
+	if f.transfers == nil {
+		f.transfers = map[types.Year]types.Cash{}
+	}
+	f.transfers[year] += amount
+	return nil
+}
+
+// Returns the room, contributions and transfers of each year from the opening of the account.
+// Up to one year of unused room is carried forward, and excess amounts are absorbed by later room.
+func (f *FHSA) Report(through types.Year) ([]FHSAYear, error) {
+	if f.Opened < fhsaFirstYear {
+		return nil, ErrorBeforeFHSA
+	}
+	report := []FHSAYear{}
+	carry, excess, lifetime := types.Cash(0), types.Cash(0), types.Cash(0)
+	for year := f.Opened; year <= through; year++ {
+		summary := FHSAYear{
+			Year:          year,
+			Contributions: f.contributions[year],
+			Transfers:     f.transfers[year],
+		}
+		room := types.MinCash(fhsaAnnualLimit+carry, fhsaLifetimeLimit-lifetime)
+		absorbed := types.MinCash(room, excess)
+		room -= absorbed
+		excess -= absorbed
+		lifetime += absorbed
+		summary.Room = room
+		used := summary.Contributions + summary.Transfers
+		within := types.MinCash(used, room)
+		excess += used - within
+		lifetime += within
+		carry = types.MinCash(fhsaAnnualLimit, room-within)
+		summary.CarryForward = carry
+		summary.Lifetime = lifetime
+		summary.Excess = excess
+		report = append(report, summary)
+	}
+	return report, nil
+}
+
+// Returns the income tax saved by deducting an amount of FHSA contributions,
+// which are deducted from income like RRSP contributions
+func FHSATaxSaved(province types.Province, year types.Year, income tax.Income, deduction types.Cash) (types.Cash, error) {
+	return RRSPTaxSaved(province, year, income, deduction)
+}
+
+// A registered savings account
+type Account uint8
+
+const (
+	RRSPAccount Account = iota // Registered retirement savings plan
+	FHSAAccount                // First home savings account
+	TFSAAccount                // Tax-free savings account
+)
+
+// Returns the name of the account
+func (a Account) String() string {
+	switch a {
+	case RRSPAccount:
+		return "RRSP"
+	case FHSAAccount:
+		return "FHSA"
+	case TFSAAccount:
+		return "TFSA"
+	}
+	return "Unknown"
+}
+
+// Returns the accounts in the order they should be filled, given the marginal rate now,
+// the expected marginal rate when the savings are withdrawn and the number of years
+// until a first home is bought (0 or less when no first home purchase is planned).
+// An FHSA is both deductible and withdrawn tax-free for a home, so it comes first when
+// the purchase is within the life of the account. An RRSP beats a TFSA when the
+// deduction is worth more than the tax on the withdrawal.
+func RecommendAccounts(marginalRate types.Percentage, withdrawalRate types.Percentage, homeHorizon int) []Account {
+	accounts := []Account{}
+	if homeHorizon > 0 && homeHorizon <= fhsaMaxYears {
+		accounts = append(accounts, FHSAAccount)
+	}
+	if marginalRate > withdrawalRate {
+		return append(accounts, RRSPAccount, TFSAAccount)
+	}
+	return append(accounts, TFSAAccount, RRSPAccount)
+}
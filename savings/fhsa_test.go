@@ -0,0 +1,101 @@
+package savings_test
+
+import (
+	"testing"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/savings"
+	"github.com/stefanovazzocell/SalaryAdvisor/tax"
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+func TestFHSAReport(t *testing.T) {
+	fhsa := savings.FHSA{Opened: 2023}
+	for _, err := range []error{
+		fhsa.Contribute(2023, 3000*types.CashDollar),
+		fhsa.TransferFromRRSP(2024, 8000*types.CashDollar),
+		fhsa.Contribute(2024, 5000*types.CashDollar),
+		fhsa.Contribute(2025, 8000*types.CashDollar),
+		fhsa.Contribute(2026, 10000*types.CashDollar),
+		fhsa.Contribute(2027, 8000*types.CashDollar),
+	} {
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	report, err := fhsa.Report(2028)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []savings.FHSAYear{
+		{Year: 2023, Room: 8000 * types.CashDollar, Contributions: 3000 * types.CashDollar,
+			CarryForward: 5000 * types.CashDollar, Lifetime: 3000 * types.CashDollar},
+		{Year: 2024, Room: 13000 * types.CashDollar, Contributions: 5000 * types.CashDollar, Transfers: 8000 * types.CashDollar,
+			Lifetime: 16000 * types.CashDollar},
+		{Year: 2025, Room: 8000 * types.CashDollar, Contributions: 8000 * types.CashDollar,
+			Lifetime: 24000 * types.CashDollar},
+		// Over-contributions are absorbed by the next year
+		{Year: 2026, Room: 8000 * types.CashDollar, Contributions: 10000 * types.CashDollar,
+			Lifetime: 32000 * types.CashDollar, Excess: 2000 * types.CashDollar},
+		// The lifetime limit caps the room
+		{Year: 2027, Room: 6000 * types.CashDollar, Contributions: 8000 * types.CashDollar,
+			Lifetime: 40000 * types.CashDollar, Excess: 2000 * types.CashDollar},
+		{Year: 2028, Lifetime: 40000 * types.CashDollar, Excess: 2000 * types.CashDollar},
+	}
+	if len(report) != len(expected) {
+		t.Fatalf("Expected %d years, got %d", len(expected), len(report))
+	}
+	for i := range expected {
+		if report[i] != expected[i] {
+			t.Fatalf("Expected %v, got %v", expected[i], report[i])
+		}
+	}
+
+	if err := fhsa.Contribute(2024, -1); err != savings.ErrorInvalidAmount {
+		t.Fatalf("Expected %v, got %v", savings.ErrorInvalidAmount, err)
+	}
+	if _, err := (&savings.FHSA{Opened: 2022}).Report(2024); err != savings.ErrorBeforeFHSA {
+		t.Fatalf("Expected %v, got %v", savings.ErrorBeforeFHSA, err)
+	}
+}
+
+func TestFHSATaxSaved(t *testing.T) {
+	income := tax.Income{Employment: 100000 * types.CashDollar}
+	fhsa, err := savings.FHSATaxSaved(types.Ontario, 2024, income, 8000*types.CashDollar)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	rrsp, err := savings.RRSPTaxSaved(types.Ontario, 2024, income, 8000*types.CashDollar)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if fhsa != rrsp || fhsa <= 0 {
+		t.Fatalf("Expected the same saving as an RRSP deduction (%s), got %s", rrsp, fhsa)
+	}
+}
+
+func TestRecommendAccounts(t *testing.T) {
+	testCases := map[struct {
+		marginalRate   types.Percentage
+		withdrawalRate types.Percentage
+		homeHorizon    int
+	}]string{
+		{40 * types.PercentagePoint, 20 * types.PercentagePoint, 5}:  "FHSA RRSP TFSA",
+		{20 * types.PercentagePoint, 30 * types.PercentagePoint, 15}: "FHSA TFSA RRSP",
+		{40 * types.PercentagePoint, 20 * types.PercentagePoint, 16}: "RRSP TFSA",
+		{20 * types.PercentagePoint, 20 * types.PercentagePoint, 0}:  "TFSA RRSP",
+	}
+
+	for testParams, testExpected := range testCases {
+		accounts := savings.RecommendAccounts(testParams.marginalRate, testParams.withdrawalRate, testParams.homeHorizon)
+		names := ""
+		for i, account := range accounts {
+			if i > 0 {
+				names += " "
+			}
+			names += account.String()
+		}
+		if names != testExpected {
+			t.Fatalf("Expected %q for %v, got %q", testExpected, testParams, names)
+		}
+	}
+}
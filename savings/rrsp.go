@@ -0,0 +1,145 @@
+package savings
+
+import (
+	"errors"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/tax"
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+const (
+	// Portion of the earned income of the previous year added to the RRSP room
+	rrspRate = 18 * types.PercentagePoint
+	// Default amount deducted at a time by the optimizer
+	defaultStep = 100 * types.CashDollar
+)
+
+var (
+	ErrorUnsupportedYear = errors.New("no RRSP limit is available for this year")
+	ErrorInvalidStep     = errors.New("the optimizer step must be positive")
+	ErrorNoDeductionYear = errors.New("at least one year is needed to deduct contributions")
+)
+
+var (
+	// RRSP dollar limits by year
+	rrspLimits = map[types.Year]types.Cash{
+		2019: 26500 * types.CashDollar,
+		2020: 27230 * types.CashDollar,
+		2021: 27830 * types.CashDollar,
+		2022: 29210 * types.CashDollar,
+		2023: 30780 * types.CashDollar,
+		2024: 31560 * types.CashDollar,
+		2025: 32490 * types.CashDollar,
+		2026: 33810 * types.CashDollar,
+	}
+	rrspFirstYear = types.Year(2019)
+	rrspLastYear  = types.Year(2026)
+)
+
+// Returns the RRSP dollar limit for a year.
+// Years after the last one with a known limit reuse the latest limit.
+func RRSPLimit(year types.Year) (types.Cash, error) {
+	if year < rrspFirstYear {
+		return 0, ErrorUnsupportedYear
+	}
+	if year > rrspLastYear {
+		year = rrspLastYear
+	}
+	return rrspLimits[year], nil
+}
+
+// Returns the RRSP deduction limit for a year, given the earned income and
+// pension adjustment of the previous year and the unused room carried forward
+func RRSPDeductionLimit(year types.Year, earnedIncome types.Cash, pensionAdjustment types.Cash, carryForward types.Cash) (types.Cash, error) {
+	limit, err := RRSPLimit(year)
+	if err != nil {
+		return 0, err
+	}
+	room, err := types.MaxCash(0, earnedIncome).Percentage(rrspRate)
+	if err != nil {
+		return 0, err
+	}
+	if room > limit {
+		room = limit
+	}
+	return types.MaxCash(0, room-pensionAdjustment) + types.MaxCash(0, carryForward), nil
+}
+
+// Returns the income tax saved by deducting an amount of RRSP contributions
+func RRSPTaxSaved(province types.Province, year types.Year, income tax.Income, deduction types.Cash) (types.Cash, error) {
+	before, err := tax.Calculate(province, year, income)
+	if err != nil {
+		return 0, err
+	}
+	income.Deductions += deduction
+	after, err := tax.Calculate(province, year, income)
+	if err != nil {
+		return 0, err
+	}
+	return before.IncomeTax() - after.IncomeTax(), nil
+}
+
+// A year in which RRSP contributions can be deducted
+type DeductionYear struct {
+	Province types.Province
+	Year     types.Year
+	Income   tax.Income // Expected income of the year, before deducting contributions
+}
+
+// A recommended RRSP contribution and the years in which to deduct it
+type RRSPPlan struct {
+	Contribution types.Cash                      // Amount to contribute now
+	Deductions   map[types.Year]types.Cash       // Amount to deduct in each year
+	Rates        map[types.Year]types.Percentage // Marginal rate in each year, after the deductions
+	TaxSaved     types.Cash
+}
+
+// Recommends how much of the budget to contribute, up to the deduction limit, and when to deduct it.
+// Contributions are deducted a step at a time in the year where the marginal saving is the highest,
+// and only as long as they save some tax. Contributions can be deducted in the year they are made
+// or carried forward to any later year. A step of 0 uses the default of $100.
+func OptimizeRRSP(limit types.Cash, budget types.Cash, years []DeductionYear, step types.Cash) (RRSPPlan, error) {
+	if len(years) == 0 {
+		return RRSPPlan{}, ErrorNoDeductionYear
+	}
+	if step == 0 {
+		step = defaultStep
+	}
+	if step < 0 {
+		return RRSPPlan{}, ErrorInvalidStep
+	}
+	plan := RRSPPlan{Deductions: make(map[types.Year]types.Cash, len(years))}
+	available := types.MinCash(types.MaxCash(0, limit), types.MaxCash(0, budget))
+	for plan.Contribution < available {
+		amount := types.MinCash(step, available-plan.Contribution)
+		best, bestSaving := -1, types.Cash(0)
+		for i, year := range years {
+			income := year.Income
+			income.Deductions += plan.Deductions[year.Year]
+			saving, err := RRSPTaxSaved(year.Province, year.Year, income, amount)
+			if err != nil {
+				return RRSPPlan{}, err
+			}
+			if saving > bestSaving {
+				best, bestSaving = i, saving
+			}
+		}
+		if best < 0 {
+			break
+		}
+		plan.Contribution += amount
+		plan.Deductions[years[best].Year] += amount
+		plan.TaxSaved += bestSaving
+	}
+	plan.Rates = make(map[types.Year]types.Percentage, len(years))
+	for _, year := range years {
+		income := year.Income
+		income.Deductions += plan.Deductions[year.Year]
+		rate, err := tax.MarginalRate(year.Province, year.Year, income)
+		if err != nil {
+			return RRSPPlan{}, err
+		}
+		plan.Rates[year.Year] = rate
+	}
+	return plan, nil
+}
@@ -0,0 +1,101 @@
+package savings_test
+
+import (
+	"testing"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/savings"
+	"github.com/stefanovazzocell/SalaryAdvisor/tax"
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+func TestRRSPDeductionLimit(t *testing.T) {
+	testCases := map[struct {
+		year              types.Year
+		earnedIncome      types.Cash
+		pensionAdjustment types.Cash
+		carryForward      types.Cash
+	}]types.Cash{
+		{2024, 0, 0, 0}:                         0,
+		{2024, 100000 * types.CashDollar, 0, 0}: 18000 * types.CashDollar,
+		{2024, 300000 * types.CashDollar, 0, 0}: 31560 * types.CashDollar,
+		{2023, 300000 * types.CashDollar, 0, 0}: 30780 * types.CashDollar,
+		{2030, 300000 * types.CashDollar, 0, 0}: 33810 * types.CashDollar,
+		{2024, -1000 * types.CashDollar, 0, 0}:  0,
+		{2024, 100000 * types.CashDollar, 3000 * types.CashDollar, 5000 * types.CashDollar}: 20000 * types.CashDollar,
+		{2024, 10000 * types.CashDollar, 3000 * types.CashDollar, 5000 * types.CashDollar}:  5000 * types.CashDollar,
+	}
+
+	for testParams, testExpected := range testCases {
+		limit, err := savings.RRSPDeductionLimit(testParams.year, testParams.earnedIncome, testParams.pensionAdjustment, testParams.carryForward)
+		if err != nil {
+			t.Fatalf("Unexpected error for %v: %v", testParams, err)
+		}
+		if limit != testExpected {
+			t.Fatalf("Expected %s for %v, got %s", testExpected, testParams, limit)
+		}
+	}
+
+	if _, err := savings.RRSPDeductionLimit(2018, 0, 0, 0); err != savings.ErrorUnsupportedYear {
+		t.Fatalf("Expected %v, got %v", savings.ErrorUnsupportedYear, err)
+	}
+}
+
+func TestRRSPTaxSaved(t *testing.T) {
+	saved, err := savings.RRSPTaxSaved(types.TestingProvince, 2024, tax.Income{Employment: 100000 * types.CashDollar}, 1000*types.CashDollar)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if saved != 205*types.CashDollar {
+		t.Fatalf("Expected $205 saved, got %s", saved)
+	}
+	saved, err = savings.RRSPTaxSaved(types.TestingProvince, 2024, tax.Income{Employment: 10000 * types.CashDollar}, 1000*types.CashDollar)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if saved != 0 {
+		t.Fatalf("Expected nothing saved below the basic personal amount, got %s", saved)
+	}
+}
+
+func TestOptimizeRRSP(t *testing.T) {
+	years := []savings.DeductionYear{
+		{Province: types.TestingProvince, Year: 2024, Income: tax.Income{Employment: 50000 * types.CashDollar}},
+		{Province: types.TestingProvince, Year: 2025, Income: tax.Income{Employment: 150000 * types.CashDollar}},
+	}
+	// Deducting in the year with the highest rate saves the most
+	plan, err := savings.OptimizeRRSP(20000*types.CashDollar, 10000*types.CashDollar, years, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if plan.Contribution != 10000*types.CashDollar || plan.Deductions[2025] != 10000*types.CashDollar || plan.Deductions[2024] != 0 {
+		t.Fatalf("Expected to deduct $10'000 in 2025, got %s contributed and %v", plan.Contribution, plan.Deductions)
+	}
+	if plan.TaxSaved != 2600*types.CashDollar {
+		t.Fatalf("Expected $2'600 saved, got %s", plan.TaxSaved)
+	}
+	if plan.Rates[2025] <= plan.Rates[2024] {
+		t.Fatalf("Expected a higher marginal rate in 2025, got %v", plan.Rates)
+	}
+	// The contribution is limited by the room
+	if plan, err = savings.OptimizeRRSP(5000*types.CashDollar, 10000*types.CashDollar, years, 1000*types.CashDollar); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if plan.Contribution != 5000*types.CashDollar {
+		t.Fatalf("Expected to contribute $5'000, got %s", plan.Contribution)
+	}
+	// Contributions which save no tax are not recommended
+	years = []savings.DeductionYear{{Province: types.TestingProvince, Year: 2024, Income: tax.Income{Employment: 15000 * types.CashDollar}}}
+	if plan, err = savings.OptimizeRRSP(20000*types.CashDollar, 10000*types.CashDollar, years, 0); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if plan.Contribution != 0 || plan.TaxSaved != 0 {
+		t.Fatalf("Expected no contribution, got %s saving %s", plan.Contribution, plan.TaxSaved)
+	}
+
+	if _, err = savings.OptimizeRRSP(0, 0, nil, 0); err != savings.ErrorNoDeductionYear {
+		t.Fatalf("Expected %v, got %v", savings.ErrorNoDeductionYear, err)
+	}
+	if _, err = savings.OptimizeRRSP(0, 0, years, -1); err != savings.ErrorInvalidStep {
+		t.Fatalf("Expected %v, got %v", savings.ErrorInvalidStep, err)
+	}
+}
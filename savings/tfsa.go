@@ -0,0 +1,188 @@
+package savings
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+const (
+	// Monthly tax on the highest excess TFSA amount of the month
+	tfsaPenaltyRate = 1 * types.PercentagePoint
+	// Age at which TFSA room starts accumulating
+	tfsaAge = 18
+)
+
+var (
+	ErrorInvalidAmount = errors.New("the amount must be positive")
+	ErrorBeforeTFSA    = errors.New("TFSAs were only introduced in 2009")
+)
+
+var (
+	// TFSA dollar limits by year
+	tfsaLimits = map[types.Year]types.Cash{
+		2009: 5000 * types.CashDollar,
+		2010: 5000 * types.CashDollar,
+		2011: 5000 * types.CashDollar,
+		2012: 5000 * types.CashDollar,
+		2013: 5500 * types.CashDollar,
+		2014: 5500 * types.CashDollar,
+		2015: 10000 * types.CashDollar,
+		2016: 5500 * types.CashDollar,
+		2017: 5500 * types.CashDollar,
+		2018: 5500 * types.CashDollar,
+		2019: 6000 * types.CashDollar,
+		2020: 6000 * types.CashDollar,
+		2021: 6000 * types.CashDollar,
+		2022: 6000 * types.CashDollar,
+		2023: 6500 * types.CashDollar,
+		2024: 7000 * types.CashDollar,
+		2025: 7000 * types.CashDollar,
+		2026: 7000 * types.CashDollar,
+	}
+	tfsaFirstYear = types.Year(2009)
+	tfsaLastYear  = types.Year(2026)
+)
+
+// Returns the TFSA dollar limit for a year.
+// Years after the last one with a known limit reuse the latest limit.
+func TFSALimit(year types.Year) (types.Cash, error) {
+	if year < tfsaFirstYear {
+		return 0, ErrorBeforeTFSA
+	}
+	if year > tfsaLastYear {
+		year = tfsaLastYear
+	}
+	return tfsaLimits[year], nil
+}
+
+// A TFSA transaction, a negative amount is a withdrawal
+type Transaction struct {
+	Date   time.Time
+	Amount types.Cash
+}
+
+// The TFSA room and contributions of a year
+type TFSAYear struct {
+	Year          types.Year
+	Limit         types.Cash // Dollar limit added to the room, if eligible
+	Room          types.Cash // Room available on January 1st
+	Contributions types.Cash
+	Withdrawals   types.Cash
+	Unused        types.Cash // Room left on December 31st, before restoring withdrawals
+	Excess        types.Cash // Highest excess amount during the year
+	Penalty       types.Cash // Tax on the excess amounts, 1% per month
+}
+
+// Tracks the TFSA room of an individual resident in Canada since turning 18
+type TFSA struct {
+	BirthYear    types.Year
+	transactions []Transaction
+}
+
+// Records a contribution
+func (t *TFSA) Contribute(date time.Time, amount types.Cash) error {
+	if amount <= 0 {
+		return ErrorInvalidAmount
+	}
+	return t.add(date, amount)
+}
+
+// Records a withdrawal
+func (t *TFSA) Withdraw(date time.Time, amount types.Cash) error {
+	if amount <= 0 {
+		return ErrorInvalidAmount
+	}
+	return t.add(date, -amount)
+}
+
+// Returns the recorded transactions in chronological order
+func (t *TFSA) Transactions() []Transaction {
+	transactions := make([]Transaction, len(t.transactions))
+	copy(transactions, t.transactions)
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return transactions[i].Date.Before(transactions[j].Date)
+	})
+	return transactions
+}
+
+// Records a new transaction
+func (t *TFSA) add(date time.Time, amount types.Cash) error {
+	if types.Year(date.Year()) < tfsaFirstYear {
+		return ErrorBeforeTFSA
+	}
+	t.transactions = append(t.transactions, Transaction{Date: date, Amount: amount})
+	return nil
+}
+
+// Returns the room, contributions and penalties of each year up to the given one.
+// Withdrawals are added back to the room on the next January 1st, except for the
+// portion which reduced an excess amount. Contributions made before turning 18 are excess amounts.
+func (t *TFSA) Report(through types.Year) ([]TFSAYear, error) {
+	eligible := t.BirthYear + tfsaAge
+	if eligible < tfsaFirstYear {
+		eligible = tfsaFirstYear
+	}
+	transactions := t.Transactions()
+	start := eligible
+	if len(transactions) > 0 && types.Year(transactions[0].Date.Year()) < start {
+		start = types.Year(transactions[0].Date.Year())
+	}
+	report := []TFSAYear{}
+	available, excess, restored := types.Cash(0), types.Cash(0), types.Cash(0)
+	next := 0
+	for year := start; year <= through; year++ {
+		summary := TFSAYear{Year: year}
+		if year >= eligible {
+			limit, err := TFSALimit(year)
+			if err != nil {
+				return nil, err
+			}
+			summary.Limit = limit
+		}
+		// New room first absorbs the excess carried from the previous year
+		available += summary.Limit + restored
+		absorbed := types.MinCash(available, excess)
+		available -= absorbed
+		excess -= absorbed
+		restored = 0
+		summary.Room = available
+		// Highest excess amount of each month
+		monthly := [12]types.Cash{excess}
+		month := 0
+		for ; next < len(transactions) && types.Year(transactions[next].Date.Year()) == year; next++ {
+			transaction := transactions[next]
+			for ; month < int(transaction.Date.Month())-1; month++ {
+				monthly[month+1] = excess
+			}
+			if transaction.Amount > 0 {
+				summary.Contributions += transaction.Amount
+				used := types.MinCash(available, transaction.Amount)
+				available -= used
+				excess += transaction.Amount - used
+			} else {
+				summary.Withdrawals -= transaction.Amount
+				reduced := types.MinCash(excess, -transaction.Amount)
+				excess -= reduced
+				restored += -transaction.Amount - reduced
+			}
+			monthly[month] = types.MaxCash(monthly[month], excess)
+		}
+		for ; month < 11; month++ {
+			monthly[month+1] = excess
+		}
+		for _, amount := range monthly {
+			summary.Excess = types.MaxCash(summary.Excess, amount)
+			penalty, err := amount.Percentage(tfsaPenaltyRate)
+			if err != nil {
+				return nil, err
+			}
+			summary.Penalty += penalty
+		}
+		summary.Unused = available
+		report = append(report, summary)
+	}
+	return report, nil
+}
@@ -0,0 +1,120 @@
+package savings_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/savings"
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestTFSARoom(t *testing.T) {
+	testCases := map[types.Year]types.Cash{
+		1980: 95000 * types.CashDollar,
+		2000: 43000 * types.CashDollar,
+		2006: 7000 * types.CashDollar,
+		2010: 0,
+	}
+
+	for birthYear, room := range testCases {
+		tfsa := savings.TFSA{BirthYear: birthYear}
+		report, err := tfsa.Report(2024)
+		if err != nil {
+			t.Fatalf("Unexpected error for %d: %v", birthYear, err)
+		}
+		if len(report) == 0 {
+			if room != 0 {
+				t.Fatalf("Expected %s of room for %d, got no report", room, birthYear)
+			}
+			continue
+		}
+		if last := report[len(report)-1]; last.Year != 2024 || last.Room != room || last.Unused != room {
+			t.Fatalf("Expected %s of room in 2024 for %d, got %s in %d", room, birthYear, last.Room, last.Year)
+		}
+	}
+}
+
+func TestTFSAWithdrawals(t *testing.T) {
+	tfsa := savings.TFSA{BirthYear: 1980}
+	for _, err := range []error{
+		tfsa.Contribute(date(2024, time.January, 10), 95000*types.CashDollar),
+		tfsa.Contribute(date(2024, time.September, 1), 5000*types.CashDollar),
+		tfsa.Withdraw(date(2024, time.June, 1), 10000*types.CashDollar),
+	} {
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if transactions := tfsa.Transactions(); len(transactions) != 3 || transactions[1].Amount != -10000*types.CashDollar {
+		t.Fatalf("Expected the withdrawal second, got %v", transactions)
+	}
+	report, err := tfsa.Report(2025)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	year := report[len(report)-2]
+	if year.Contributions != 100000*types.CashDollar || year.Withdrawals != 10000*types.CashDollar {
+		t.Fatalf("Unexpected contributions %s and withdrawals %s", year.Contributions, year.Withdrawals)
+	}
+	// Withdrawn room only comes back next year
+	if year.Excess != 5000*types.CashDollar || year.Penalty != 200*types.CashDollar || year.Unused != 0 {
+		t.Fatalf("Expected a $5'000 excess for 4 months, got %s and a %s penalty", year.Excess, year.Penalty)
+	}
+	if next := report[len(report)-1]; next.Room != 12000*types.CashDollar || next.Penalty != 0 {
+		t.Fatalf("Expected $12'000 of room in 2025 and no penalty, got %s and %s", next.Room, next.Penalty)
+	}
+}
+
+func TestTFSAExcess(t *testing.T) {
+	// Withdrawals reducing an excess are not added back
+	tfsa := savings.TFSA{BirthYear: 1980}
+	if err := tfsa.Contribute(date(2024, time.March, 15), 100000*types.CashDollar); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := tfsa.Withdraw(date(2024, time.May, 20), 5000*types.CashDollar); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	report, err := tfsa.Report(2025)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if year := report[len(report)-2]; year.Penalty != 150*types.CashDollar {
+		t.Fatalf("Expected a $150 penalty, got %s", year.Penalty)
+	}
+	if next := report[len(report)-1]; next.Room != 7000*types.CashDollar {
+		t.Fatalf("Expected $7'000 of room in 2025, got %s", next.Room)
+	}
+
+	// Contributions before turning 18 are excess until room is available
+	tfsa = savings.TFSA{BirthYear: 2010}
+	if err := tfsa.Contribute(date(2026, time.February, 1), 1000*types.CashDollar); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if report, err = tfsa.Report(2028); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []savings.TFSAYear{
+		{Year: 2026, Contributions: 1000 * types.CashDollar, Excess: 1000 * types.CashDollar, Penalty: 110 * types.CashDollar},
+		{Year: 2027, Excess: 1000 * types.CashDollar, Penalty: 120 * types.CashDollar},
+		{Year: 2028, Limit: 7000 * types.CashDollar, Room: 6000 * types.CashDollar, Unused: 6000 * types.CashDollar},
+	}
+	if len(report) != len(expected) {
+		t.Fatalf("Expected %d years, got %d", len(expected), len(report))
+	}
+	for i := range expected {
+		if report[i] != expected[i] {
+			t.Fatalf("Expected %v, got %v", expected[i], report[i])
+		}
+	}
+
+	if err := tfsa.Contribute(date(2008, time.December, 31), types.CashDollar); err != savings.ErrorBeforeTFSA {
+		t.Fatalf("Expected %v, got %v", savings.ErrorBeforeTFSA, err)
+	}
+	if err := tfsa.Withdraw(date(2024, time.December, 31), 0); err != savings.ErrorInvalidAmount {
+		t.Fatalf("Expected %v, got %v", savings.ErrorInvalidAmount, err)
+	}
+}
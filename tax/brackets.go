@@ -0,0 +1,42 @@
+package tax
+
+import (
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+// A rate that applies to the amounts above a threshold
+type bracket struct {
+	threshold types.Cash
+	rate      types.Percentage
+}
+
+// Progressive rates, sorted by threshold
+type brackets []bracket
+
+// Returns the tax due on the given amount
+func (b brackets) tax(amount types.Cash) (types.Cash, error) {
+	tax := types.Cash(0)
+	for i, current := range b {
+		if amount <= current.threshold {
+			break
+		}
+		upper := amount
+		if i+1 < len(b) && b[i+1].threshold < amount {
+			upper = b[i+1].threshold
+		}
+		bracketTax, err := (upper - current.threshold).Percentage(current.rate)
+		if err != nil {
+			return 0, err
+		}
+		tax += bracketTax
+	}
+	return tax, nil
+}
+
+// Returns the lowest rate, used for non-refundable credits
+func (b brackets) lowest() types.Percentage {
+	if len(b) == 0 {
+		return 0
+	}
+	return b[0].rate
+}
@@ -0,0 +1,320 @@
+package tax
+
+import (
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+const (
+	// Age from which the age amount can be claimed
+	seniorAge = 65
+	// Age from which CPP or QPP contributions can be stopped while receiving the pension
+	pensionOptOutAge = 65
+	// Age from which CPP or QPP contributions are no longer due
+	pensionStopAge = 70
+)
+
+// The income of an individual for a year
+type Income struct {
+	Employment           types.Cash // Employment income, including bonuses and taxable benefits
+	EligibleDividends    types.Cash // Eligible dividends received, before the gross-up
+	NonEligibleDividends types.Cash // Other dividends received, before the gross-up
+	BusinessRevenue      types.Cash // Gross revenue from self-employment
+	BusinessExpenses     types.Cash // Deductible expenses of the business
+	Interest             types.Cash // Interest and other investment income from Canadian sources
+	Pension              types.Cash // Eligible pension income, such as a life annuity from a pension plan
+	OAS                  types.Cash // Old Age Security pension received
+	Foreign              types.Cash // Foreign property income, such as dividends and interest, in CAD and before foreign taxes
+	ForeignTaxPaid       types.Cash // Income tax withheld by other countries on the foreign property income
+	Other                types.Cash // Other fully taxable income
+	Deductions           types.Cash // Deductions from income, such as RRSP contributions or union dues
+	Age                  int        // Age at the end of the year, for the age amount and CPP or QPP contributions
+	PensionOptOut        bool       // Elected to stop CPP or QPP contributions while receiving the pension, from 65
+	ExemptFromEI         bool       // Employment is not insurable, as for the owners of a corporation
+	SelfEmployedEI       bool       // Opted in to EI special benefits for self-employed people
+	HasSpouse            bool       // Married or common-law, to claim the spouse amount
+	SpouseNetIncome      types.Cash // Net income of the spouse, reducing the spouse amount
+	TransferredAmounts   types.Cash // Unused federal credit amounts transferred from the spouse, not applied provincially
+}
+
+// Returns the net income from self-employment, negative for a business loss
+func (i Income) Business() types.Cash {
+	return i.BusinessRevenue - i.BusinessExpenses
+}
+
+// Returns true if CPP or QPP contributions are due on the earnings
+func (i Income) contributesToPension() bool {
+	if i.Age >= pensionStopAge {
+		return false
+	}
+	return !i.PensionOptOut || i.Age < pensionOptOutAge
+}
+
+// Returns the income with the amounts of another income added,
+// the status and spouse details are kept from the receiver
+func (i Income) Add(other Income) Income {
+	i.Employment += other.Employment
+	i.EligibleDividends += other.EligibleDividends
+	i.NonEligibleDividends += other.NonEligibleDividends
+	i.BusinessRevenue += other.BusinessRevenue
+	i.BusinessExpenses += other.BusinessExpenses
+	i.Interest += other.Interest
+	i.Pension += other.Pension
+	i.OAS += other.OAS
+	i.Foreign += other.Foreign
+	i.ForeignTaxPaid += other.ForeignTaxPaid
+	i.Other += other.Other
+	i.Deductions += other.Deductions
+	return i
+}
+
+// The taxes and contributions of an individual for a year
+type Result struct {
+	Province      types.Province
+	Year          types.Year
+	TotalIncome   types.Cash // Income from all sources, including the gross-up on dividends
+	GrossUp       types.Cash // Gross-up on dividends, taxed but not received
+	ForeignTax    types.Cash // Income tax paid to other countries
+	OASRecovery   types.Cash // OAS pension repaid through the recovery tax
+	NetIncome     types.Cash // Total income minus deductions, used for income-tested benefits
+	TaxableIncome types.Cash
+	FederalTax    types.Cash // Federal tax, after the foreign tax credit and the refundable Quebec abatement
+	ProvincialTax types.Cash // Provincial tax, including surtaxes and premiums, after the foreign tax credit
+	CPP           types.Cash // CPP or QPP contributions
+	EI            types.Cash // Employment insurance premiums
+	PPIP          types.Cash // Provincial parental insurance plan premiums
+	UnusedAmounts types.Cash // Federal credit amounts not needed to eliminate the tax, which can be transferred to a spouse
+}
+
+// Returns the total income tax
+func (r Result) IncomeTax() types.Cash {
+	return r.FederalTax + r.ProvincialTax
+}
+
+// Returns the total payroll contributions
+func (r Result) Contributions() types.Cash {
+	return r.CPP + r.EI + r.PPIP
+}
+
+// Returns the income left after taxes and contributions
+func (r Result) NetPay() types.Cash {
+	return r.TotalIncome - r.GrossUp - r.ForeignTax - r.OASRecovery - r.IncomeTax() - r.Contributions()
+}
+
+// Computes taxes and contributions for a resident of a province in a given year.
+// Years after the last one with known data reuse the latest parameters.
+func Calculate(province types.Province, year types.Year, income Income) (Result, error) {
+	params, provincial, err := provincialParametersFor(province, year)
+	if err != nil {
+		return Result{}, err
+	}
+	contributions, err := params.payroll.employee(province, income.Employment)
+	if err != nil {
+		return Result{}, err
+	}
+	if income.ExemptFromEI {
+		contributions.ei = 0
+	}
+	selfEmployed, err := params.payroll.selfEmployed(province, income.Employment, income.Business(), income.SelfEmployedEI)
+	if err != nil {
+		return Result{}, err
+	}
+	contributions = contributions.add(selfEmployed)
+	if !income.contributesToPension() {
+		contributions.pension, contributions.pensionBase = 0, 0
+	}
+	dividends, err := params.federal.dividends(income)
+	if err != nil {
+		return Result{}, err
+	}
+	result := Result{
+		Province:   province,
+		Year:       year,
+		GrossUp:    dividends.eligible + dividends.nonEligible - income.EligibleDividends - income.NonEligibleDividends,
+		ForeignTax: types.MaxCash(0, income.ForeignTaxPaid),
+		CPP:        contributions.pension,
+		EI:         contributions.ei,
+		PPIP:       contributions.ppip,
+	}
+	result.TotalIncome = income.Employment + income.Business() + income.Interest + income.Pension + income.OAS + income.Foreign + income.Other +
+		dividends.eligible + dividends.nonEligible
+	foreign, err := params.federal.foreign(income)
+	if err != nil {
+		return Result{}, err
+	}
+	deductions := contributions.deductible() + foreign.deductible + income.Deductions
+	result.NetIncome = types.MaxCash(0, result.TotalIncome-deductions)
+	if result.OASRecovery, err = params.federal.oasRecovery(result.NetIncome, income.OAS); err != nil {
+		return Result{}, err
+	}
+	result.NetIncome -= result.OASRecovery
+	result.TaxableIncome = result.NetIncome
+	// Federal tax
+	federalBPA, err := params.federal.bpa(result.NetIncome)
+	if err != nil {
+		return Result{}, err
+	}
+	credits := federalBPA +
+		types.MinCash(params.federal.canadaEmploymentAmount, types.MaxCash(0, income.Employment)) +
+		contributions.creditable() + types.MaxCash(0, income.TransferredAmounts)
+	if income.HasSpouse {
+		credits += types.MaxCash(0, federalBPA-types.MaxCash(0, income.SpouseNetIncome))
+	}
+	ageAmount, err := params.federal.age(income.Age, result.NetIncome)
+	if err != nil {
+		return Result{}, err
+	}
+	transferable := types.MinCash(params.federal.pensionAmount, types.MaxCash(0, income.Pension)) + ageAmount
+	result.FederalTax, err = netTax(params.federal.brackets, result.TaxableIncome, credits+transferable)
+	if err != nil {
+		return Result{}, err
+	}
+	result.UnusedAmounts, err = unusedAmounts(params.federal.brackets, result.TaxableIncome, credits, transferable)
+	if err != nil {
+		return Result{}, err
+	}
+	federalDTC, err := dividends.credit(params.federal.eligibleDividendCredit, params.federal.nonEligibleDividendCredit)
+	if err != nil {
+		return Result{}, err
+	}
+	// Basic federal tax, which limits the foreign tax credit and sets the Quebec abatement
+	result.FederalTax = types.MaxCash(0, result.FederalTax-federalDTC)
+	federalFTC, err := foreign.credit(result.FederalTax, foreign.creditable, result.NetIncome)
+	if err != nil {
+		return Result{}, err
+	}
+	if province == types.Quebec {
+		abatement, err := result.FederalTax.Percentage(params.federal.quebecAbatement)
+		if err != nil {
+			return Result{}, err
+		}
+		result.FederalTax -= abatement
+	}
+	result.FederalTax -= federalFTC
+	// Provincial tax
+	provincialBPA := provincial.basicPersonalAmount
+	if provincial.followsFederalBPA {
+		provincialBPA = federalBPA
+	}
+	credits = provincialBPA
+	if income.HasSpouse {
+		credits += types.MaxCash(0, provincialBPA-types.MaxCash(0, income.SpouseNetIncome))
+	}
+	credits += types.MinCash(provincial.pensionAmount, types.MaxCash(0, income.Pension))
+	if !provincial.noContributionCredit {
+		credits += contributions.creditable()
+	}
+	result.ProvincialTax, err = netTax(provincial.brackets, result.TaxableIncome, credits)
+	if err != nil {
+		return Result{}, err
+	}
+	provincialDTC, err := dividends.credit(provincial.eligibleDividendCredit, provincial.nonEligibleDividendCredit)
+	if err != nil {
+		return Result{}, err
+	}
+	result.ProvincialTax = types.MaxCash(0, result.ProvincialTax-provincialDTC)
+	// The surtax applies to the tax net of all non-refundable credits, including the dividend tax credit
+	surtax, err := provincial.surtax.tax(result.ProvincialTax)
+	if err != nil {
+		return Result{}, err
+	}
+	result.ProvincialTax += surtax
+	// The foreign tax credit is limited by the tax before the health premium
+	provincialFTC, err := foreign.credit(result.ProvincialTax, foreign.creditable-federalFTC, result.NetIncome)
+	if err != nil {
+		return Result{}, err
+	}
+	premium, err := healthPremium(provincial.healthPremium, result.TaxableIncome)
+	if err != nil {
+		return Result{}, err
+	}
+	result.ProvincialTax += premium - provincialFTC
+	return result, nil
+}
+
+// Returns the tax on taxable income after non-refundable credits
+func netTax(rates brackets, taxable types.Cash, credits types.Cash) (types.Cash, error) {
+	tax, err := rates.tax(taxable)
+	if err != nil {
+		return 0, err
+	}
+	creditsValue, err := credits.Percentage(rates.lowest())
+	if err != nil {
+		return 0, err
+	}
+	return types.MaxCash(0, tax-creditsValue), nil
+}
+
+// Returns the transferable credit amounts which are not needed to eliminate the tax,
+// after claiming the other credits
+func unusedAmounts(rates brackets, taxable types.Cash, credits types.Cash, transferable types.Cash) (types.Cash, error) {
+	tax, err := rates.tax(taxable)
+	if err != nil {
+		return 0, err
+	}
+	if rates.lowest() == 0 {
+		return transferable, nil
+	}
+	needed, err := tax.MulDiv(types.Cash(100*types.PercentagePoint), types.Cash(rates.lowest()))
+	if err != nil {
+		return 0, err
+	}
+	return types.MinCash(transferable, types.MaxCash(0, credits+transferable-needed)), nil
+}
+
+// Returns the federal basic personal amount, which is gradually
+// reduced for incomes in the second to last bracket
+func (f federalParameters) bpa(netIncome types.Cash) (types.Cash, error) {
+	if len(f.brackets) < 2 {
+		return f.basicPersonalAmount, nil
+	}
+	start := f.brackets[len(f.brackets)-2].threshold
+	end := f.brackets[len(f.brackets)-1].threshold
+	if netIncome <= start {
+		return f.basicPersonalAmount, nil
+	}
+	if netIncome >= end {
+		return f.basicPersonalAmountMin, nil
+	}
+	reduction, err := (f.basicPersonalAmount - f.basicPersonalAmountMin).MulDiv(netIncome-start, end-start)
+	if err != nil {
+		return 0, err
+	}
+	return f.basicPersonalAmount - reduction, nil
+}
+
+// Returns the age amount, which is reduced for higher net incomes
+func (f federalParameters) age(age int, netIncome types.Cash) (types.Cash, error) {
+	if age < seniorAge {
+		return 0, nil
+	}
+	reduction, err := types.MaxCash(0, netIncome-f.ageThreshold).Percentage(f.ageReduction)
+	if err != nil {
+		return 0, err
+	}
+	return types.MaxCash(0, f.ageAmount-reduction), nil
+}
+
+// Returns the OAS pension repaid given the net income before the repayment
+func (f federalParameters) oasRecovery(netIncome types.Cash, oas types.Cash) (types.Cash, error) {
+	recovery, err := types.MaxCash(0, netIncome-f.oasThreshold).Percentage(f.oasRecoveryRate)
+	if err != nil {
+		return 0, err
+	}
+	return types.MinCash(types.MaxCash(0, oas), recovery), nil
+}
+
+// Returns the premium due on the given taxable income
+func healthPremium(tiers []premiumTier, taxable types.Cash) (types.Cash, error) {
+	premium := types.Cash(0)
+	for _, tier := range tiers {
+		if taxable <= tier.threshold {
+			break
+		}
+		tierPremium, err := (taxable - tier.threshold).Percentage(tier.rate)
+		if err != nil {
+			return 0, err
+		}
+		premium += types.MinCash(tierPremium, tier.cap)
+	}
+	return premium, nil
+}
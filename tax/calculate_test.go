@@ -0,0 +1,287 @@
+package tax_test
+
+import (
+	"testing"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/tax"
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+func TestCalculate(t *testing.T) {
+	testCases := map[struct {
+		province   types.Province
+		year       types.Year
+		employment types.Cash
+	}]struct {
+		federalTax    types.Cash
+		provincialTax types.Cash
+		cpp           types.Cash
+		ei            types.Cash
+		ppip          types.Cash
+	}{
+		{types.Ontario, 2024, 0}:                                 {0, 0, 0, 0, 0},
+		{types.Ontario, 2024, 30000 * types.CashDollar}:          {16180875, 10840757, 15767500, 4980000, 0},
+		{types.Ontario, 2024, 100000 * types.CashDollar}:         {140448320, 69861078, 40555000, 10491200, 0},
+		{types.Ontario, 2030, 100000 * types.CashDollar}:         {140448320, 69861078, 40555000, 10491200, 0},
+		{types.Quebec, 2024, 100000 * types.CashDollar}:          {116595518, 137239400, 43480000, 8342400, 4643600},
+		{types.Alberta, 2024, 300000 * types.CashDollar}:         {714608870, 321506180, 40555000, 10491200, 0},
+		{types.TestingProvince, 2024, 100000 * types.CashDollar}: {140448320, 0, 40555000, 10491200, 0},
+	}
+
+	for testParams, testExpected := range testCases {
+		result, err := tax.Calculate(testParams.province, testParams.year, tax.Income{Employment: testParams.employment})
+		if err != nil {
+			t.Fatalf("Unexpected error for %v: %v", testParams, err)
+		}
+		if result.FederalTax != testExpected.federalTax || result.ProvincialTax != testExpected.provincialTax {
+			t.Fatalf("Expected %d federal and %d provincial tax for %v, instead got %d and %d",
+				testExpected.federalTax, testExpected.provincialTax, testParams,
+				result.FederalTax, result.ProvincialTax)
+		}
+		if result.CPP != testExpected.cpp || result.EI != testExpected.ei || result.PPIP != testExpected.ppip {
+			t.Fatalf("Expected contributions %d, %d, %d for %v, instead got %d, %d, %d",
+				testExpected.cpp, testExpected.ei, testExpected.ppip, testParams,
+				result.CPP, result.EI, result.PPIP)
+		}
+		if net := result.NetPay(); net != testParams.employment-result.IncomeTax()-result.Contributions() {
+			t.Fatalf("Unexpected net pay %s for %v", net, testParams)
+		}
+	}
+}
+
+func TestCalculateIncome(t *testing.T) {
+	// Deductions and other income only affect income tax
+	base, err := tax.Calculate(types.Ontario, 2023, tax.Income{Employment: 80000 * types.CashDollar})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	deducted, err := tax.Calculate(types.Ontario, 2023, tax.Income{Employment: 80000 * types.CashDollar, Deductions: 10000 * types.CashDollar})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	other, err := tax.Calculate(types.Ontario, 2023, tax.Income{Employment: 80000 * types.CashDollar, Other: 10000 * types.CashDollar})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if deducted.NetIncome != base.NetIncome-10000*types.CashDollar || deducted.IncomeTax() >= base.IncomeTax() {
+		t.Fatalf("Expected deductions to lower the tax, got %+v and %+v", base, deducted)
+	}
+	if other.TotalIncome != base.TotalIncome+10000*types.CashDollar || other.IncomeTax() <= base.IncomeTax() {
+		t.Fatalf("Expected other income to raise the tax, got %+v and %+v", base, other)
+	}
+	if deducted.Contributions() != base.Contributions() || other.Contributions() != base.Contributions() {
+		t.Fatalf("Expected identical contributions, got %s, %s and %s", base.Contributions(), deducted.Contributions(), other.Contributions())
+	}
+	// Errors
+	if _, err := tax.Calculate(types.Ontario, 2000, tax.Income{}); err != tax.ErrorUnsupportedYear {
+		t.Fatalf("Expected ErrorUnsupportedYear, got %v", err)
+	}
+	if _, err := tax.Calculate(types.Province(200), 2024, tax.Income{}); err != tax.ErrorUnsupportedProvince {
+		t.Fatalf("Expected ErrorUnsupportedProvince, got %v", err)
+	}
+}
+
+func TestCalculateDividends(t *testing.T) {
+	// The dividend tax credit covers the federal tax on small eligible dividends
+	result, err := tax.Calculate(types.TestingProvince, 2024, tax.Income{EligibleDividends: 50000 * types.CashDollar})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.GrossUp != 19000*types.CashDollar || result.TotalIncome != 69000*types.CashDollar {
+		t.Fatalf("Expected a gross-up of $19'000 on $69'000, got %s on %s", result.GrossUp, result.TotalIncome)
+	}
+	if result.FederalTax != 0 || result.NetPay() != 50000*types.CashDollar {
+		t.Fatalf("Expected no federal tax, got %s and a net pay of %s", result.FederalTax, result.NetPay())
+	}
+	// Dividends are taxed less than other income, non-eligible ones more than eligible ones
+	other, err := tax.Calculate(types.Ontario, 2024, tax.Income{Other: 150000 * types.CashDollar})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	nonEligible, err := tax.Calculate(types.Ontario, 2024, tax.Income{NonEligibleDividends: 150000 * types.CashDollar})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	eligible, err := tax.Calculate(types.Ontario, 2024, tax.Income{EligibleDividends: 150000 * types.CashDollar})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !(other.IncomeTax() > nonEligible.IncomeTax() && nonEligible.IncomeTax() > eligible.IncomeTax()) {
+		t.Fatalf("Expected other income (%s) > non-eligible (%s) > eligible (%s)",
+			other.IncomeTax(), nonEligible.IncomeTax(), eligible.IncomeTax())
+	}
+	// The Ontario surtax applies after the dividend tax credit:
+	// $345'000 grossed-up is taxed $37'524.54, minus $626.15 for the basic personal amount
+	// and $34'500 of dividend tax credit, leaving $2'398.40 under the surtax threshold,
+	// plus the $900 health premium
+	ontario, err := tax.Calculate(types.Ontario, 2024, tax.Income{EligibleDividends: 250000 * types.CashDollar})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ontario.ProvincialTax != 32983951 {
+		t.Fatalf("Expected $3'298.40 of Ontario tax, got %s", ontario.ProvincialTax)
+	}
+	// Owners of a corporation don't pay EI premiums
+	exempt, err := tax.Calculate(types.Ontario, 2024, tax.Income{Employment: 60000 * types.CashDollar, ExemptFromEI: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if exempt.EI != 0 || exempt.CPP == 0 {
+		t.Fatalf("Expected CPP but no EI, got %s and %s", exempt.CPP, exempt.EI)
+	}
+}
+
+func TestCalculateSelfEmployment(t *testing.T) {
+	income := tax.Income{BusinessRevenue: 80000 * types.CashDollar, BusinessExpenses: 20000 * types.CashDollar}
+	if business := income.Business(); business != 60000*types.CashDollar {
+		t.Fatalf("Expected $60'000 of business income, got %s", business)
+	}
+	result, err := tax.Calculate(types.TestingProvince, 2024, income)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// Both portions of CPP, with the employer one and the enhancement deducted
+	if result.CPP != 67235000 || result.EI != 0 {
+		t.Fatalf("Expected 67235000 of CPP and no EI, got %d and %d", result.CPP, result.EI)
+	}
+	if result.TotalIncome != 60000*types.CashDollar || result.NetIncome != 560732500 {
+		t.Fatalf("Expected a total income of $60'000 and 560732500 of net income, got %s and %d", result.TotalIncome, result.NetIncome)
+	}
+	if result.FederalTax != 56470687 {
+		t.Fatalf("Expected 56470687 of federal tax, got %d", result.FederalTax)
+	}
+	// EI special benefits are optional
+	income.SelfEmployedEI = true
+	if result, err = tax.Calculate(types.TestingProvince, 2024, income); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.EI != 996*types.CashDollar {
+		t.Fatalf("Expected $996 of EI, got %s", result.EI)
+	}
+	// Contributions on employment count towards the maximums
+	income = tax.Income{Employment: 70000 * types.CashDollar, BusinessRevenue: 10000 * types.CashDollar}
+	if result, err = tax.Calculate(types.TestingProvince, 2024, income); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.CPP != 41835000 {
+		t.Fatalf("Expected 41835000 of CPP, got %d", result.CPP)
+	}
+	// Business losses reduce other income
+	income = tax.Income{Employment: 50000 * types.CashDollar, BusinessExpenses: 10000 * types.CashDollar}
+	if result, err = tax.Calculate(types.TestingProvince, 2024, income); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.TotalIncome != 40000*types.CashDollar || result.CPP != 27667500 {
+		t.Fatalf("Expected $40'000 of total income and 27667500 of CPP, got %s and %d", result.TotalIncome, result.CPP)
+	}
+	// Quebec self-employed pay the higher QPIP rate, partly deductible
+	result, err = tax.Calculate(types.Quebec, 2024, tax.Income{BusinessRevenue: 50000 * types.CashDollar})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.PPIP != 4390000 {
+		t.Fatalf("Expected 4390000 of QPIP, got %d", result.PPIP)
+	}
+}
+
+func TestCalculateInvestmentIncome(t *testing.T) {
+	// Interest is fully taxable
+	interest, err := tax.Calculate(types.Ontario, 2024, tax.Income{Employment: 80000 * types.CashDollar, Interest: 5000 * types.CashDollar})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	other, err := tax.Calculate(types.Ontario, 2024, tax.Income{Employment: 80000 * types.CashDollar, Other: 5000 * types.CashDollar})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if interest != other {
+		t.Fatalf("Expected interest to be taxed like other income, got %v and %v", interest, other)
+	}
+	// Foreign taxes up to 15% are fully credited
+	income := tax.Income{Employment: 100000 * types.CashDollar, Foreign: 10000 * types.CashDollar}
+	untaxed, err := tax.Calculate(types.Ontario, 2024, income)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	income.ForeignTaxPaid = 1500 * types.CashDollar
+	credited, err := tax.Calculate(types.Ontario, 2024, income)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if credited.IncomeTax() != untaxed.IncomeTax()-1500*types.CashDollar || credited.NetPay() != untaxed.NetPay() {
+		t.Fatalf("Expected an income tax of %s and the same net pay of %s, got %s and %s",
+			untaxed.IncomeTax()-1500*types.CashDollar, untaxed.NetPay(), credited.IncomeTax(), credited.NetPay())
+	}
+	// The federal credit is limited to the share of federal tax on the foreign income, the provinces credit the rest
+	if credited.FederalTax <= untaxed.FederalTax-1500*types.CashDollar || credited.ProvincialTax >= untaxed.ProvincialTax {
+		t.Fatalf("Expected both federal and provincial credits, got %s and %s", credited.FederalTax, credited.ProvincialTax)
+	}
+	// Foreign taxes above 15% are deducted instead
+	income.ForeignTaxPaid = 3000 * types.CashDollar
+	deducted, err := tax.Calculate(types.Ontario, 2024, income)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if deducted.NetIncome != untaxed.NetIncome-1500*types.CashDollar || deducted.ForeignTax != 3000*types.CashDollar {
+		t.Fatalf("Expected a net income of %s and $3'000 of foreign tax, got %s and %s",
+			untaxed.NetIncome-1500*types.CashDollar, deducted.NetIncome, deducted.ForeignTax)
+	}
+	// The credit is limited to the Canadian tax on the foreign income
+	foreign, err := tax.Calculate(types.Ontario, 2024, tax.Income{Foreign: 20000 * types.CashDollar, ForeignTaxPaid: 3000 * types.CashDollar})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if foreign.IncomeTax() != 0 || foreign.NetPay() != 17000*types.CashDollar {
+		t.Fatalf("Expected no income tax and $17'000 of net pay, got %s and %s", foreign.IncomeTax(), foreign.NetPay())
+	}
+	// The limits use the tax before the health premium and the Quebec abatement:
+	// $3'644.25 of federal tax and $1'393.85 of Ontario tax are credited, the $450 premium is not
+	income = tax.Income{Foreign: 40000 * types.CashDollar, ForeignTaxPaid: 6000 * types.CashDollar}
+	ontario, err := tax.Calculate(types.Ontario, 2024, income)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ontario.FederalTax != 0 || ontario.ProvincialTax != 450*types.CashDollar {
+		t.Fatalf("Expected only the $450 health premium, got %s and %s", ontario.FederalTax, ontario.ProvincialTax)
+	}
+	// The refundable abatement is 16.5% of the basic federal tax, before the credit
+	quebec, err := tax.Calculate(types.Quebec, 2024, income)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if quebec.FederalTax != -6013012 {
+		t.Fatalf("Expected a $601.30 federal refund, got %s", quebec.FederalTax)
+	}
+}
+
+func TestCalculateSenior(t *testing.T) {
+	testCases := map[tax.Income]struct {
+		federalTax  types.Cash
+		oasRecovery types.Cash
+		netIncome   types.Cash
+	}{
+		// The age amount and pension amount lower the tax
+		{Age: 70, Pension: 30000 * types.CashDollar, OAS: 8500 * types.CashDollar}: {18007500, 0, 38500 * types.CashDollar},
+		{Age: 64, Pension: 30000 * types.CashDollar, OAS: 8500 * types.CashDollar}: {31192500, 0, 38500 * types.CashDollar},
+		// The OAS pension is recovered above the threshold
+		{Age: 70, Pension: 100000 * types.CashDollar, OAS: 8500 * types.CashDollar}: {0, 26254500, 1058745500},
+		{Age: 70, Pension: 200000 * types.CashDollar, OAS: 8500 * types.CashDollar}: {0, 8500 * types.CashDollar, 200000 * types.CashDollar},
+	}
+
+	for income, expected := range testCases {
+		result, err := tax.Calculate(types.TestingProvince, 2024, income)
+		if err != nil {
+			t.Fatalf("Unexpected error for %+v: %v", income, err)
+		}
+		if result.OASRecovery != expected.oasRecovery || result.NetIncome != expected.netIncome {
+			t.Fatalf("Expected %d recovered and %d net income for %+v, got %d and %d",
+				expected.oasRecovery, expected.netIncome, income, result.OASRecovery, result.NetIncome)
+		}
+		if expected.federalTax != 0 && result.FederalTax != expected.federalTax {
+			t.Fatalf("Expected %d federal tax for %+v, got %d", expected.federalTax, income, result.FederalTax)
+		}
+		if result.NetPay() != result.TotalIncome-result.OASRecovery-result.IncomeTax() {
+			t.Fatalf("Unexpected net pay %s for %+v", result.NetPay(), income)
+		}
+	}
+}
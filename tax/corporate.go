@@ -0,0 +1,73 @@
+package tax
+
+import (
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+var (
+	// Share of the income taxed at the general rate added to the general rate income pool
+	gripRate = types.MustParsePercentage("72")
+)
+
+// The taxes of a Canadian-controlled private corporation on its active business income
+type CorporateResult struct {
+	Province      types.Province
+	Year          types.Year
+	Income        types.Cash // Active business income
+	SmallBusiness types.Cash // Income eligible for the small business deduction
+	FederalTax    types.Cash
+	ProvincialTax types.Cash
+	GRIP          types.Cash // Addition to the general rate income pool, which can be paid as eligible dividends
+}
+
+// Returns the total corporate tax
+func (r CorporateResult) Tax() types.Cash {
+	return r.FederalTax + r.ProvincialTax
+}
+
+// Returns the income left in the corporation after taxes
+func (r CorporateResult) AfterTax() types.Cash {
+	return r.Income - r.Tax()
+}
+
+// Computes the taxes of a corporation with a permanent establishment in a province.
+// The federal business limit is used for all provinces and is not shared with associated corporations.
+func CorporateTax(province types.Province, year types.Year, income types.Cash) (CorporateResult, error) {
+	params, provincial, err := provincialParametersFor(province, year)
+	if err != nil {
+		return CorporateResult{}, err
+	}
+	result := CorporateResult{
+		Province:      province,
+		Year:          year,
+		Income:        types.MaxCash(0, income),
+		SmallBusiness: types.MinCash(types.MaxCash(0, income), params.federal.smallBusinessLimit),
+	}
+	general := result.Income - result.SmallBusiness
+	result.FederalTax, err = corporateTax(result.SmallBusiness, general, params.federal.smallBusinessRate, params.federal.generalCorporateRate)
+	if err != nil {
+		return CorporateResult{}, err
+	}
+	result.ProvincialTax, err = corporateTax(result.SmallBusiness, general, provincial.smallBusinessRate, provincial.generalCorporateRate)
+	if err != nil {
+		return CorporateResult{}, err
+	}
+	result.GRIP, err = general.Percentage(gripRate)
+	if err != nil {
+		return CorporateResult{}, err
+	}
+	return result, nil
+}
+
+// Returns the tax on income taxed at the small business and general rates
+func corporateTax(smallBusiness types.Cash, general types.Cash, smallBusinessRate types.Percentage, generalRate types.Percentage) (types.Cash, error) {
+	smallBusinessTax, err := smallBusiness.Percentage(smallBusinessRate)
+	if err != nil {
+		return 0, err
+	}
+	generalTax, err := general.Percentage(generalRate)
+	if err != nil {
+		return 0, err
+	}
+	return smallBusinessTax + generalTax, nil
+}
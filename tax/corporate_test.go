@@ -0,0 +1,45 @@
+package tax_test
+
+import (
+	"testing"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/tax"
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+func TestCorporateTax(t *testing.T) {
+	testCases := map[struct {
+		province types.Province
+		year     types.Year
+		income   types.Cash
+	}]struct {
+		federalTax    types.Cash
+		provincialTax types.Cash
+		grip          types.Cash
+	}{
+		{types.Ontario, 2024, -1000 * types.CashDollar}:     {0, 0, 0},
+		{types.Ontario, 2024, 100000 * types.CashDollar}:    {9000 * types.CashDollar, 3200 * types.CashDollar, 0},
+		{types.Ontario, 2024, 600000 * types.CashDollar}:    {60000 * types.CashDollar, 27500 * types.CashDollar, 72000 * types.CashDollar},
+		{types.Alberta, 2023, 500000 * types.CashDollar}:    {45000 * types.CashDollar, 10000 * types.CashDollar, 0},
+		{types.TestingProvince, 2024, 1 * types.CashDollar}: {9 * types.CashCent, 0, 0},
+	}
+
+	for testParams, testExpected := range testCases {
+		result, err := tax.CorporateTax(testParams.province, testParams.year, testParams.income)
+		if err != nil {
+			t.Fatalf("Unexpected error for %v: %v", testParams, err)
+		}
+		if result.FederalTax != testExpected.federalTax || result.ProvincialTax != testExpected.provincialTax || result.GRIP != testExpected.grip {
+			t.Fatalf("Expected %d federal tax, %d provincial tax and %d GRIP for %v, instead got %d, %d and %d",
+				testExpected.federalTax, testExpected.provincialTax, testExpected.grip, testParams,
+				result.FederalTax, result.ProvincialTax, result.GRIP)
+		}
+		if result.AfterTax() != result.Income-result.Tax() {
+			t.Fatalf("Unexpected after-tax income %s for %v", result.AfterTax(), testParams)
+		}
+	}
+
+	if _, err := tax.CorporateTax(types.Ontario, 2020, 0); err != tax.ErrorUnsupportedYear {
+		t.Fatalf("Expected %v, got %v", tax.ErrorUnsupportedYear, err)
+	}
+}
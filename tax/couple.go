@@ -0,0 +1,115 @@
+package tax
+
+import (
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+const (
+	// Number of intervals in each pass of the pension split search
+	pensionSplitSteps = 10
+	// Number of passes refining the pension split search around the best split
+	pensionSplitRefinements = 4
+)
+
+var (
+	// Maximum portion of the eligible pension income which can be split with a spouse
+	pensionSplitRate = types.MustParsePercentage("50")
+)
+
+// The taxes of a married or common-law couple
+type CoupleResult struct {
+	First        Result
+	Second       Result
+	PensionSplit types.Cash // Pension income allocated from the first to the second spouse, negative for the other way
+}
+
+// Returns the combined net pay of the couple
+func (c CoupleResult) NetPay() types.Cash {
+	return c.First.NetPay() + c.Second.NetPay()
+}
+
+// Returns the family net income, used for income-tested benefits
+func (c CoupleResult) FamilyIncome() types.Cash {
+	return c.First.NetIncome + c.Second.NetIncome
+}
+
+// Computes the taxes of a couple resident in the same province, claiming the spouse amount,
+// transferring unused credit amounts and electing the pension income split that results
+// in the highest combined net pay. The split is searched on a coarse grid refined around
+// the best split, to within a few dollars.
+func CalculateCouple(province types.Province, year types.Year, first Income, second Income) (CoupleResult, error) {
+	firstMax, err := types.MaxCash(0, first.Pension).Percentage(pensionSplitRate)
+	if err != nil {
+		return CoupleResult{}, err
+	}
+	secondMax, err := types.MaxCash(0, second.Pension).Percentage(pensionSplitRate)
+	if err != nil {
+		return CoupleResult{}, err
+	}
+	best, err := calculateSplit(province, year, first, second, 0)
+	if err != nil {
+		return CoupleResult{}, err
+	}
+	// Search a coarse grid of splits, then refine the grid around the best one
+	tried := map[types.Cash]bool{0: true}
+	low, high := -secondMax, firstMax
+	for pass := 0; pass <= pensionSplitRefinements && low < high; pass++ {
+		step := (high - low) / pensionSplitSteps
+		for i := 0; i <= pensionSplitSteps; i++ {
+			split := low + step*types.Cash(i)
+			if i == pensionSplitSteps {
+				split = high
+			}
+			if tried[split] {
+				continue
+			}
+			tried[split] = true
+			result, err := calculateSplit(province, year, first, second, split)
+			if err != nil {
+				return CoupleResult{}, err
+			}
+			if result.NetPay() > best.NetPay() {
+				best = result
+			}
+		}
+		low = types.MaxCash(-secondMax, best.PensionSplit-step)
+		high = types.MinCash(firstMax, best.PensionSplit+step)
+	}
+	return best, nil
+}
+
+// Computes the taxes of a couple given the pension income allocated from the first to the second spouse
+func calculateSplit(province types.Province, year types.Year, first Income, second Income, split types.Cash) (CoupleResult, error) {
+	first.Pension -= split
+	second.Pension += split
+	// Net incomes don't depend on credits
+	firstAlone, err := Calculate(province, year, first)
+	if err != nil {
+		return CoupleResult{}, err
+	}
+	secondAlone, err := Calculate(province, year, second)
+	if err != nil {
+		return CoupleResult{}, err
+	}
+	first.HasSpouse, first.SpouseNetIncome = true, secondAlone.NetIncome
+	second.HasSpouse, second.SpouseNetIncome = true, firstAlone.NetIncome
+	firstSpouse, err := Calculate(province, year, first)
+	if err != nil {
+		return CoupleResult{}, err
+	}
+	secondSpouse, err := Calculate(province, year, second)
+	if err != nil {
+		return CoupleResult{}, err
+	}
+	// Transfer the amounts the other spouse doesn't need
+	first.TransferredAmounts += secondSpouse.UnusedAmounts
+	second.TransferredAmounts += firstSpouse.UnusedAmounts
+	result := CoupleResult{PensionSplit: split}
+	if result.First, err = Calculate(province, year, first); err != nil {
+		return CoupleResult{}, err
+	}
+	if result.Second, err = Calculate(province, year, second); err != nil {
+		return CoupleResult{}, err
+	}
+	return result, nil
+}
@@ -0,0 +1,82 @@
+package tax_test
+
+import (
+	"testing"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/tax"
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+func TestPensionCredits(t *testing.T) {
+	// Eligible pension income earns the pension amount
+	other, err := tax.Calculate(types.Ontario, 2024, tax.Income{Employment: 50000 * types.CashDollar, Other: 10000 * types.CashDollar})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	pension, err := tax.Calculate(types.Ontario, 2024, tax.Income{Employment: 50000 * types.CashDollar, Pension: 10000 * types.CashDollar})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if other.FederalTax-pension.FederalTax != 300*types.CashDollar || other.ProvincialTax-pension.ProvincialTax != 889810 {
+		t.Fatalf("Expected $300 federal and 889810 provincial savings, got %s and %d",
+			other.FederalTax-pension.FederalTax, other.ProvincialTax-pension.ProvincialTax)
+	}
+	// Amounts not needed to eliminate the tax can be transferred
+	low, err := tax.Calculate(types.TestingProvince, 2024, tax.Income{Pension: 2000 * types.CashDollar})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if low.UnusedAmounts != 2000*types.CashDollar || pension.UnusedAmounts != 0 {
+		t.Fatalf("Expected $2'000 and nothing unused, got %s and %s", low.UnusedAmounts, pension.UnusedAmounts)
+	}
+	transferred, err := tax.Calculate(types.TestingProvince, 2024, tax.Income{Employment: 50000 * types.CashDollar, TransferredAmounts: 2000 * types.CashDollar})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	alone, err := tax.Calculate(types.TestingProvince, 2024, tax.Income{Employment: 50000 * types.CashDollar})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if alone.FederalTax-transferred.FederalTax != 300*types.CashDollar {
+		t.Fatalf("Expected $300 saved with the transfer, got %s", alone.FederalTax-transferred.FederalTax)
+	}
+}
+
+func TestCalculateCouple(t *testing.T) {
+	// A spouse without income gives the spouse amount
+	earner := tax.Income{Employment: 80000 * types.CashDollar}
+	couple, err := tax.CalculateCouple(types.TestingProvince, 2024, earner, tax.Income{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	alone, err := tax.Calculate(types.TestingProvince, 2024, earner)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if alone.FederalTax-couple.First.FederalTax != 23557500 || couple.Second.IncomeTax() != 0 || couple.PensionSplit != 0 {
+		t.Fatalf("Expected 23557500 saved with the spouse amount, got %d and %+v", alone.FederalTax-couple.First.FederalTax, couple)
+	}
+	if couple.NetPay() != couple.First.NetPay() || couple.FamilyIncome() != alone.NetIncome {
+		t.Fatalf("Unexpected net pay %s or family income %s", couple.NetPay(), couple.FamilyIncome())
+	}
+
+	// Pension income is split to lower the combined tax
+	retiree := tax.Income{Pension: 120000 * types.CashDollar}
+	couple, err = tax.CalculateCouple(types.Ontario, 2024, tax.Income{}, retiree)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if couple.PensionSplit != -60000*types.CashDollar {
+		t.Fatalf("Expected half the pension to be split, got %s", couple.PensionSplit)
+	}
+	if couple.First.TotalIncome != 60000*types.CashDollar || couple.Second.TotalIncome != 60000*types.CashDollar {
+		t.Fatalf("Expected $60'000 each, got %s and %s", couple.First.TotalIncome, couple.Second.TotalIncome)
+	}
+	single, err := tax.Calculate(types.Ontario, 2024, retiree)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if couple.NetPay() <= single.NetPay() {
+		t.Fatalf("Expected splitting to increase the net pay over %s, got %s", single.NetPay(), couple.NetPay())
+	}
+}
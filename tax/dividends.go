@@ -0,0 +1,40 @@
+package tax
+
+import (
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+// Taxable amounts of dividends, after the gross-up
+type grossedUpDividends struct {
+	eligible    types.Cash
+	nonEligible types.Cash
+}
+
+// Returns the taxable amounts of the dividends received
+func (f federalParameters) dividends(income Income) (grossedUpDividends, error) {
+	eligibleGrossUp, err := income.EligibleDividends.Percentage(f.eligibleGrossUp)
+	if err != nil {
+		return grossedUpDividends{}, err
+	}
+	nonEligibleGrossUp, err := income.NonEligibleDividends.Percentage(f.nonEligibleGrossUp)
+	if err != nil {
+		return grossedUpDividends{}, err
+	}
+	return grossedUpDividends{
+		eligible:    income.EligibleDividends + eligibleGrossUp,
+		nonEligible: income.NonEligibleDividends + nonEligibleGrossUp,
+	}, nil
+}
+
+// Returns the dividend tax credit given the rates on the taxable amounts
+func (d grossedUpDividends) credit(eligibleRate types.Percentage, nonEligibleRate types.Percentage) (types.Cash, error) {
+	eligible, err := d.eligible.Percentage(eligibleRate)
+	if err != nil {
+		return 0, err
+	}
+	nonEligible, err := d.nonEligible.Percentage(nonEligibleRate)
+	if err != nil {
+		return 0, err
+	}
+	return eligible + nonEligible, nil
+}
@@ -0,0 +1,42 @@
+package tax
+
+import (
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+// Foreign taxes paid on property income
+type foreignTaxes struct {
+	income     types.Cash // Foreign property income
+	creditable types.Cash // Foreign taxes eligible for the foreign tax credit
+	deductible types.Cash // Foreign taxes above the rate limit, deducted from income instead
+}
+
+// Returns how the foreign taxes paid are relieved, taxes above the rate limit
+// are deductible instead of creditable.
+// The limit only applies to property income, foreign business and employment
+// income are not modelled.
+func (f federalParameters) foreign(income Income) (foreignTaxes, error) {
+	paid := types.MaxCash(0, income.ForeignTaxPaid)
+	limit, err := types.MaxCash(0, income.Foreign).Percentage(f.foreignTaxLimit)
+	if err != nil {
+		return foreignTaxes{}, err
+	}
+	return foreignTaxes{
+		income:     types.MaxCash(0, income.Foreign),
+		creditable: types.MinCash(paid, limit),
+		deductible: paid - types.MinCash(paid, limit),
+	}, nil
+}
+
+// Returns the foreign tax credit against the given tax, which is limited
+// to the portion of the tax attributable to the foreign income
+func (f foreignTaxes) credit(tax types.Cash, available types.Cash, netIncome types.Cash) (types.Cash, error) {
+	if available <= 0 || tax <= 0 || netIncome <= 0 {
+		return 0, nil
+	}
+	limit, err := tax.MulDiv(types.MinCash(f.income, netIncome), netIncome)
+	if err != nil {
+		return 0, err
+	}
+	return types.MinCash(available, limit), nil
+}
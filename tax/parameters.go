@@ -0,0 +1,206 @@
+package tax
+
+import (
+	"errors"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+var (
+	ErrorUnsupportedYear     = errors.New("no tax data is available for this year")
+	ErrorUnsupportedProvince = errors.New("no tax data is available for this province")
+)
+
+// Federal tax parameters for a year
+type federalParameters struct {
+	brackets                  brackets
+	basicPersonalAmount       types.Cash       // Basic personal amount for lower incomes
+	basicPersonalAmountMin    types.Cash       // Basic personal amount for the highest incomes
+	canadaEmploymentAmount    types.Cash       // Maximum Canada employment amount
+	pensionAmount             types.Cash       // Maximum pension income amount
+	ageAmount                 types.Cash       // Maximum age amount, from 65 years old
+	ageThreshold              types.Cash       // Net income above which the age amount is reduced
+	ageReduction              types.Percentage // Reduction rate of the age amount
+	oasThreshold              types.Cash       // Net income above which the OAS pension is recovered
+	oasRecoveryRate           types.Percentage // Recovery rate of the OAS pension
+	quebecAbatement           types.Percentage // Refundable abatement of the basic federal tax for Quebec residents
+	eligibleGrossUp           types.Percentage // Gross-up of eligible dividends
+	nonEligibleGrossUp        types.Percentage // Gross-up of non-eligible dividends
+	eligibleDividendCredit    types.Percentage // Dividend tax credit, on grossed-up eligible dividends
+	nonEligibleDividendCredit types.Percentage // Dividend tax credit, on grossed-up non-eligible dividends
+	smallBusinessRate         types.Percentage // Corporate tax rate on income eligible for the small business deduction
+	generalCorporateRate      types.Percentage // Corporate tax rate on other active business income
+	smallBusinessLimit        types.Cash       // Business limit for the small business deduction
+	foreignTaxLimit           types.Percentage // Maximum rate of foreign tax on property income eligible for the credit
+}
+
+// Payroll contributions parameters for a year
+type payrollParameters struct {
+	cppRate              types.Percentage // Employee CPP rate, base plus first enhancement
+	cppBaseRate          types.Percentage // Portion of the CPP rate eligible for the credit
+	qppRate              types.Percentage // Employee QPP rate, base plus first enhancement
+	qppBaseRate          types.Percentage // Portion of the QPP rate eligible for the credit
+	secondRate           types.Percentage // CPP2 and QPP2 rate
+	exemption            types.Cash       // Basic exemption
+	ympe                 types.Cash       // Year's maximum pensionable earnings
+	yampe                types.Cash       // Year's additional maximum pensionable earnings
+	eiRate               types.Percentage // Employee EI rate
+	eiRateQuebec         types.Percentage // Employee EI rate in Quebec
+	eiMaxInsurable       types.Cash       // Maximum insurable earnings
+	qpipRate             types.Percentage // Employee QPIP rate
+	qpipRateSelfEmployed types.Percentage // Self-employed QPIP rate
+	qpipMaxInsurable     types.Cash       // Maximum QPIP insurable earnings
+}
+
+// Provincial tax parameters for a year
+type provincialParameters struct {
+	brackets                  brackets
+	basicPersonalAmount       types.Cash
+	pensionAmount             types.Cash       // Maximum pension income amount
+	followsFederalBPA         bool             // The basic personal amount is reduced like the federal one
+	noContributionCredit      bool             // No credit for CPP/QPP, EI and QPIP contributions
+	surtax                    brackets         // Surtax on the basic provincial tax
+	healthPremium             []premiumTier    // Health premium based on taxable income
+	eligibleDividendCredit    types.Percentage // Dividend tax credit, on grossed-up eligible dividends
+	nonEligibleDividendCredit types.Percentage // Dividend tax credit, on grossed-up non-eligible dividends
+	smallBusinessRate         types.Percentage // Corporate tax rate on income eligible for the small business deduction
+	generalCorporateRate      types.Percentage // Corporate tax rate on other active business income
+}
+
+// A tier of a premium which grows at rate above a threshold, up to a cap
+type premiumTier struct {
+	threshold types.Cash
+	rate      types.Percentage
+	cap       types.Cash
+}
+
+// All the tax parameters for a year
+type parameters struct {
+	federal   federalParameters
+	payroll   payrollParameters
+	provinces map[types.Province]provincialParameters
+}
+
+var (
+	yearParameters = map[types.Year]parameters{
+		2023: {
+			federal: federalParameters{
+				brackets: brackets{
+					{0, types.MustParsePercentage("15")},
+					{53359 * types.CashDollar, types.MustParsePercentage("20.5")},
+					{106717 * types.CashDollar, types.MustParsePercentage("26")},
+					{165430 * types.CashDollar, types.MustParsePercentage("29")},
+					{235675 * types.CashDollar, types.MustParsePercentage("33")},
+				},
+				basicPersonalAmount:       15000 * types.CashDollar,
+				basicPersonalAmountMin:    13521 * types.CashDollar,
+				canadaEmploymentAmount:    1368 * types.CashDollar,
+				pensionAmount:             2000 * types.CashDollar,
+				ageAmount:                 8396 * types.CashDollar,
+				ageThreshold:              42335 * types.CashDollar,
+				ageReduction:              types.MustParsePercentage("15"),
+				oasThreshold:              86912 * types.CashDollar,
+				oasRecoveryRate:           types.MustParsePercentage("15"),
+				quebecAbatement:           types.MustParsePercentage("16.5"),
+				eligibleGrossUp:           types.MustParsePercentage("38"),
+				nonEligibleGrossUp:        types.MustParsePercentage("15"),
+				eligibleDividendCredit:    types.MustParsePercentage("15.0198"),
+				nonEligibleDividendCredit: types.MustParsePercentage("9.0301"),
+				smallBusinessRate:         types.MustParsePercentage("9"),
+				generalCorporateRate:      types.MustParsePercentage("15"),
+				smallBusinessLimit:        500000 * types.CashDollar,
+				foreignTaxLimit:           types.MustParsePercentage("15"),
+			},
+			payroll: payrollParameters{
+				cppRate:              types.MustParsePercentage("5.95"),
+				cppBaseRate:          types.MustParsePercentage("4.95"),
+				qppRate:              types.MustParsePercentage("6.4"),
+				qppBaseRate:          types.MustParsePercentage("5.4"),
+				secondRate:           0,
+				exemption:            3500 * types.CashDollar,
+				ympe:                 66600 * types.CashDollar,
+				yampe:                66600 * types.CashDollar,
+				eiRate:               types.MustParsePercentage("1.63"),
+				eiRateQuebec:         types.MustParsePercentage("1.27"),
+				eiMaxInsurable:       61500 * types.CashDollar,
+				qpipRate:             types.MustParsePercentage("0.494"),
+				qpipRateSelfEmployed: types.MustParsePercentage("0.878"),
+				qpipMaxInsurable:     91000 * types.CashDollar,
+			},
+			provinces: provinces2023,
+		},
+		2024: {
+			federal: federalParameters{
+				brackets: brackets{
+					{0, types.MustParsePercentage("15")},
+					{55867 * types.CashDollar, types.MustParsePercentage("20.5")},
+					{111733 * types.CashDollar, types.MustParsePercentage("26")},
+					{173205 * types.CashDollar, types.MustParsePercentage("29")},
+					{246752 * types.CashDollar, types.MustParsePercentage("33")},
+				},
+				basicPersonalAmount:       15705 * types.CashDollar,
+				basicPersonalAmountMin:    14156 * types.CashDollar,
+				canadaEmploymentAmount:    1433 * types.CashDollar,
+				pensionAmount:             2000 * types.CashDollar,
+				ageAmount:                 8790 * types.CashDollar,
+				ageThreshold:              44325 * types.CashDollar,
+				ageReduction:              types.MustParsePercentage("15"),
+				oasThreshold:              90997 * types.CashDollar,
+				oasRecoveryRate:           types.MustParsePercentage("15"),
+				quebecAbatement:           types.MustParsePercentage("16.5"),
+				eligibleGrossUp:           types.MustParsePercentage("38"),
+				nonEligibleGrossUp:        types.MustParsePercentage("15"),
+				eligibleDividendCredit:    types.MustParsePercentage("15.0198"),
+				nonEligibleDividendCredit: types.MustParsePercentage("9.0301"),
+				smallBusinessRate:         types.MustParsePercentage("9"),
+				generalCorporateRate:      types.MustParsePercentage("15"),
+				smallBusinessLimit:        500000 * types.CashDollar,
+				foreignTaxLimit:           types.MustParsePercentage("15"),
+			},
+			payroll: payrollParameters{
+				cppRate:              types.MustParsePercentage("5.95"),
+				cppBaseRate:          types.MustParsePercentage("4.95"),
+				qppRate:              types.MustParsePercentage("6.4"),
+				qppBaseRate:          types.MustParsePercentage("5.4"),
+				secondRate:           types.MustParsePercentage("4"),
+				exemption:            3500 * types.CashDollar,
+				ympe:                 68500 * types.CashDollar,
+				yampe:                73200 * types.CashDollar,
+				eiRate:               types.MustParsePercentage("1.66"),
+				eiRateQuebec:         types.MustParsePercentage("1.32"),
+				eiMaxInsurable:       63200 * types.CashDollar,
+				qpipRate:             types.MustParsePercentage("0.494"),
+				qpipRateSelfEmployed: types.MustParsePercentage("0.878"),
+				qpipMaxInsurable:     94000 * types.CashDollar,
+			},
+			provinces: provinces2024,
+		},
+	}
+	firstYear = types.Year(2023)
+	lastYear  = types.Year(2024)
+)
+
+// Returns the parameters for a given year.
+// Years after the last one with known data reuse the latest parameters.
+func parametersFor(year types.Year) (parameters, error) {
+	if year < firstYear {
+		return parameters{}, ErrorUnsupportedYear
+	}
+	if year > lastYear {
+		year = lastYear
+	}
+	return yearParameters[year], nil
+}
+
+// Returns the provincial parameters for a given year
+func provincialParametersFor(province types.Province, year types.Year) (parameters, provincialParameters, error) {
+	params, err := parametersFor(year)
+	if err != nil {
+		return parameters{}, provincialParameters{}, err
+	}
+	provincial, ok := params.provinces[province]
+	if !ok {
+		return parameters{}, provincialParameters{}, ErrorUnsupportedProvince
+	}
+	return params, provincial, nil
+}
@@ -0,0 +1,133 @@
+package tax
+
+import (
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+// Payroll contributions of an individual
+type contributions struct {
+	pension        types.Cash // CPP or QPP contributions, including the second additional contributions
+	pensionBase    types.Cash // Portion of the pension contributions eligible for the credit, the rest is deductible
+	ei             types.Cash // Employment insurance premiums
+	ppip           types.Cash // Provincial parental insurance plan premiums
+	ppipDeductible types.Cash // Portion of the PPIP premiums which is deductible instead of eligible for the credit
+}
+
+// Returns the sum of two sets of contributions
+func (c contributions) add(other contributions) contributions {
+	return contributions{
+		pension:        c.pension + other.pension,
+		pensionBase:    c.pensionBase + other.pensionBase,
+		ei:             c.ei + other.ei,
+		ppip:           c.ppip + other.ppip,
+		ppipDeductible: c.ppipDeductible + other.ppipDeductible,
+	}
+}
+
+// Returns the portion of contributions which is deducted from income
+func (c contributions) deductible() types.Cash {
+	return c.pension - c.pensionBase + c.ppipDeductible
+}
+
+// Returns the portion of contributions which is eligible for the non-refundable credit
+func (c contributions) creditable() types.Cash {
+	return c.pensionBase + c.ei + c.ppip - c.ppipDeductible
+}
+
+// Computes the payroll contributions of an employee on the given earnings
+func (p payrollParameters) employee(province types.Province, earnings types.Cash) (contributions, error) {
+	c := contributions{}
+	var err error
+	c.pension, c.pensionBase, err = p.pension(province, earnings)
+	if err != nil {
+		return contributions{}, err
+	}
+	// EI
+	c.ei, err = p.insurance(province, earnings)
+	if err != nil {
+		return contributions{}, err
+	}
+	// QPIP
+	if province == types.Quebec {
+		c.ppip, err = types.MinCash(types.MaxCash(0, earnings), p.qpipMaxInsurable).Percentage(p.qpipRate)
+		if err != nil {
+			return contributions{}, err
+		}
+	}
+	return c, nil
+}
+
+// Computes the contributions on self-employment earnings, on top of those of an employee.
+// Both the employee and employer portions of CPP or QPP are paid, and the employer portion is deductible.
+// EI premiums are only paid when opting in to special benefits.
+func (p payrollParameters) selfEmployed(province types.Province, employment types.Cash, business types.Cash, ei bool) (contributions, error) {
+	employment = types.MaxCash(0, employment)
+	total := employment + types.MaxCash(0, business)
+	c := contributions{}
+	employeePension, employeeBase, err := p.pension(province, employment)
+	if err != nil {
+		return contributions{}, err
+	}
+	totalPension, totalBase, err := p.pension(province, total)
+	if err != nil {
+		return contributions{}, err
+	}
+	c.pension = 2 * (totalPension - employeePension)
+	c.pensionBase = totalBase - employeeBase
+	if ei {
+		employeeEI, err := p.insurance(province, employment)
+		if err != nil {
+			return contributions{}, err
+		}
+		totalEI, err := p.insurance(province, total)
+		if err != nil {
+			return contributions{}, err
+		}
+		c.ei = totalEI - employeeEI
+	}
+	if province == types.Quebec {
+		insurable := types.MinCash(total, p.qpipMaxInsurable) - types.MinCash(employment, p.qpipMaxInsurable)
+		c.ppip, err = insurable.Percentage(p.qpipRateSelfEmployed)
+		if err != nil {
+			return contributions{}, err
+		}
+		employeePPIP, err := insurable.Percentage(p.qpipRate)
+		if err != nil {
+			return contributions{}, err
+		}
+		c.ppipDeductible = c.ppip - employeePPIP
+	}
+	return c, nil
+}
+
+// Returns the employee CPP or QPP contributions on the given earnings,
+// and the portion of them eligible for the credit
+func (p payrollParameters) pension(province types.Province, earnings types.Cash) (types.Cash, types.Cash, error) {
+	rate, baseRate := p.cppRate, p.cppBaseRate
+	if province == types.Quebec {
+		rate, baseRate = p.qppRate, p.qppBaseRate
+	}
+	pensionable := types.MaxCash(0, types.MinCash(earnings, p.ympe)-p.exemption)
+	pension, err := pensionable.Percentage(rate)
+	if err != nil {
+		return 0, 0, err
+	}
+	base, err := pensionable.Percentage(baseRate)
+	if err != nil {
+		return 0, 0, err
+	}
+	second, err := types.MaxCash(0, types.MinCash(earnings, p.yampe)-p.ympe).Percentage(p.secondRate)
+	if err != nil {
+		return 0, 0, err
+	}
+	return pension + second, base, nil
+}
+
+// Returns the employee EI premiums on the given earnings
+func (p payrollParameters) insurance(province types.Province, earnings types.Cash) (types.Cash, error) {
+	rate := p.eiRate
+	if province == types.Quebec {
+		rate = p.eiRateQuebec
+	}
+	return types.MinCash(types.MaxCash(0, earnings), p.eiMaxInsurable).Percentage(rate)
+}
@@ -0,0 +1,430 @@
+package tax
+
+import (
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+// Provincial low-income reductions and supplements are not modelled.
+// Quebec's retirement income amount is income-tested and not modelled.
+// Provincial age amounts are not modelled.
+// Provincial spouse amounts are approximated by the basic personal amount.
+// Unused pension amounts are only transferred between spouses federally, not provincially.
+// Corporate rates changing during a year, such as Saskatchewan's, are averaged over the year.
+
+var (
+	ontarioHealthPremium = []premiumTier{
+		{20000 * types.CashDollar, types.MustParsePercentage("6"), 300 * types.CashDollar},
+		{36000 * types.CashDollar, types.MustParsePercentage("6"), 150 * types.CashDollar},
+		{48000 * types.CashDollar, types.MustParsePercentage("25"), 150 * types.CashDollar},
+		{72000 * types.CashDollar, types.MustParsePercentage("25"), 150 * types.CashDollar},
+		{200000 * types.CashDollar, types.MustParsePercentage("25"), 150 * types.CashDollar},
+	}
+
+	provinces2023 = map[types.Province]provincialParameters{
+		types.Alberta: {
+			brackets: brackets{
+				{0, types.MustParsePercentage("10")},
+				{142292 * types.CashDollar, types.MustParsePercentage("12")},
+				{170751 * types.CashDollar, types.MustParsePercentage("13")},
+				{227668 * types.CashDollar, types.MustParsePercentage("14")},
+				{341502 * types.CashDollar, types.MustParsePercentage("15")},
+			},
+			basicPersonalAmount:       21003 * types.CashDollar,
+			pensionAmount:             1653 * types.CashDollar,
+			eligibleDividendCredit:    types.MustParsePercentage("8.12"),
+			nonEligibleDividendCredit: types.MustParsePercentage("2.18"),
+			smallBusinessRate:         types.MustParsePercentage("2"),
+			generalCorporateRate:      types.MustParsePercentage("8"),
+		},
+		types.BritishColumbia: {
+			brackets: brackets{
+				{0, types.MustParsePercentage("5.06")},
+				{45654 * types.CashDollar, types.MustParsePercentage("7.7")},
+				{91310 * types.CashDollar, types.MustParsePercentage("10.5")},
+				{104835 * types.CashDollar, types.MustParsePercentage("12.29")},
+				{127299 * types.CashDollar, types.MustParsePercentage("14.7")},
+				{172602 * types.CashDollar, types.MustParsePercentage("16.8")},
+				{240716 * types.CashDollar, types.MustParsePercentage("20.5")},
+			},
+			basicPersonalAmount:       11981 * types.CashDollar,
+			pensionAmount:             1000 * types.CashDollar,
+			eligibleDividendCredit:    types.MustParsePercentage("12"),
+			nonEligibleDividendCredit: types.MustParsePercentage("1.96"),
+			smallBusinessRate:         types.MustParsePercentage("2"),
+			generalCorporateRate:      types.MustParsePercentage("12"),
+		},
+		types.Manitoba: {
+			brackets: brackets{
+				{0, types.MustParsePercentage("10.8")},
+				{36842 * types.CashDollar, types.MustParsePercentage("12.75")},
+				{79625 * types.CashDollar, types.MustParsePercentage("17.4")},
+			},
+			basicPersonalAmount:       15000 * types.CashDollar,
+			pensionAmount:             1000 * types.CashDollar,
+			eligibleDividendCredit:    types.MustParsePercentage("8"),
+			nonEligibleDividendCredit: types.MustParsePercentage("0.7835"),
+			smallBusinessRate:         types.MustParsePercentage("0"),
+			generalCorporateRate:      types.MustParsePercentage("12"),
+		},
+		types.NewBrunswick: {
+			brackets: brackets{
+				{0, types.MustParsePercentage("9.4")},
+				{47715 * types.CashDollar, types.MustParsePercentage("14")},
+				{95431 * types.CashDollar, types.MustParsePercentage("16")},
+				{176756 * types.CashDollar, types.MustParsePercentage("19.5")},
+			},
+			basicPersonalAmount:       12458 * types.CashDollar,
+			pensionAmount:             1000 * types.CashDollar,
+			eligibleDividendCredit:    types.MustParsePercentage("14"),
+			nonEligibleDividendCredit: types.MustParsePercentage("2.75"),
+			smallBusinessRate:         types.MustParsePercentage("2.5"),
+			generalCorporateRate:      types.MustParsePercentage("14"),
+		},
+		types.NewfoundlandAndLabrador: {
+			brackets: brackets{
+				{0, types.MustParsePercentage("8.7")},
+				{41457 * types.CashDollar, types.MustParsePercentage("14.5")},
+				{82913 * types.CashDollar, types.MustParsePercentage("15.8")},
+				{148027 * types.CashDollar, types.MustParsePercentage("17.8")},
+				{207239 * types.CashDollar, types.MustParsePercentage("19.8")},
+				{264750 * types.CashDollar, types.MustParsePercentage("20.8")},
+				{529500 * types.CashDollar, types.MustParsePercentage("21.3")},
+				{1059000 * types.CashDollar, types.MustParsePercentage("21.8")},
+			},
+			basicPersonalAmount:       10382 * types.CashDollar,
+			pensionAmount:             1000 * types.CashDollar,
+			eligibleDividendCredit:    types.MustParsePercentage("6.3"),
+			nonEligibleDividendCredit: types.MustParsePercentage("3.2"),
+			smallBusinessRate:         types.MustParsePercentage("3"),
+			generalCorporateRate:      types.MustParsePercentage("15"),
+		},
+		types.NorthwestTerritories: {
+			brackets: brackets{
+				{0, types.MustParsePercentage("5.9")},
+				{48326 * types.CashDollar, types.MustParsePercentage("8.6")},
+				{96655 * types.CashDollar, types.MustParsePercentage("12.2")},
+				{157139 * types.CashDollar, types.MustParsePercentage("14.05")},
+			},
+			basicPersonalAmount:       16593 * types.CashDollar,
+			pensionAmount:             1000 * types.CashDollar,
+			eligibleDividendCredit:    types.MustParsePercentage("11.5"),
+			nonEligibleDividendCredit: types.MustParsePercentage("6"),
+			smallBusinessRate:         types.MustParsePercentage("2"),
+			generalCorporateRate:      types.MustParsePercentage("11.5"),
+		},
+		types.NovaScotia: {
+			brackets: brackets{
+				{0, types.MustParsePercentage("8.79")},
+				{29590 * types.CashDollar, types.MustParsePercentage("14.95")},
+				{59180 * types.CashDollar, types.MustParsePercentage("16.67")},
+				{93000 * types.CashDollar, types.MustParsePercentage("17.5")},
+				{150000 * types.CashDollar, types.MustParsePercentage("21")},
+			},
+			basicPersonalAmount:       8481 * types.CashDollar,
+			pensionAmount:             1173 * types.CashDollar,
+			eligibleDividendCredit:    types.MustParsePercentage("8.85"),
+			nonEligibleDividendCredit: types.MustParsePercentage("2.99"),
+			smallBusinessRate:         types.MustParsePercentage("2.5"),
+			generalCorporateRate:      types.MustParsePercentage("14"),
+		},
+		types.Nunavut: {
+			brackets: brackets{
+				{0, types.MustParsePercentage("4")},
+				{50877 * types.CashDollar, types.MustParsePercentage("7")},
+				{101754 * types.CashDollar, types.MustParsePercentage("9")},
+				{165429 * types.CashDollar, types.MustParsePercentage("11.5")},
+			},
+			basicPersonalAmount:       17925 * types.CashDollar,
+			pensionAmount:             2000 * types.CashDollar,
+			eligibleDividendCredit:    types.MustParsePercentage("5.51"),
+			nonEligibleDividendCredit: types.MustParsePercentage("2.61"),
+			smallBusinessRate:         types.MustParsePercentage("3"),
+			generalCorporateRate:      types.MustParsePercentage("12"),
+		},
+		types.Ontario: {
+			brackets: brackets{
+				{0, types.MustParsePercentage("5.05")},
+				{49231 * types.CashDollar, types.MustParsePercentage("9.15")},
+				{98463 * types.CashDollar, types.MustParsePercentage("11.16")},
+				{150000 * types.CashDollar, types.MustParsePercentage("12.16")},
+				{220000 * types.CashDollar, types.MustParsePercentage("13.16")},
+			},
+			basicPersonalAmount:       11865 * types.CashDollar,
+			pensionAmount:             1641 * types.CashDollar,
+			eligibleDividendCredit:    types.MustParsePercentage("10"),
+			nonEligibleDividendCredit: types.MustParsePercentage("2.9863"),
+			smallBusinessRate:         types.MustParsePercentage("3.2"),
+			generalCorporateRate:      types.MustParsePercentage("11.5"),
+			surtax: brackets{
+				{0, 0},
+				{5315 * types.CashDollar, types.MustParsePercentage("20")},
+				{6802 * types.CashDollar, types.MustParsePercentage("56")},
+			},
+			healthPremium: ontarioHealthPremium,
+		},
+		types.PrinceEdwardIsland: {
+			brackets: brackets{
+				{0, types.MustParsePercentage("9.8")},
+				{31984 * types.CashDollar, types.MustParsePercentage("13.8")},
+				{63969 * types.CashDollar, types.MustParsePercentage("16.7")},
+			},
+			basicPersonalAmount:       12750 * types.CashDollar,
+			pensionAmount:             1000 * types.CashDollar,
+			eligibleDividendCredit:    types.MustParsePercentage("10.5"),
+			nonEligibleDividendCredit: types.MustParsePercentage("1.3"),
+			smallBusinessRate:         types.MustParsePercentage("1"),
+			generalCorporateRate:      types.MustParsePercentage("16"),
+			surtax: brackets{
+				{0, 0},
+				{12500 * types.CashDollar, types.MustParsePercentage("10")},
+			},
+		},
+		types.Quebec: {
+			brackets: brackets{
+				{0, types.MustParsePercentage("14")},
+				{49275 * types.CashDollar, types.MustParsePercentage("19")},
+				{98540 * types.CashDollar, types.MustParsePercentage("24")},
+				{119910 * types.CashDollar, types.MustParsePercentage("25.75")},
+			},
+			basicPersonalAmount:       17183 * types.CashDollar,
+			eligibleDividendCredit:    types.MustParsePercentage("11.7"),
+			nonEligibleDividendCredit: types.MustParsePercentage("3.42"),
+			smallBusinessRate:         types.MustParsePercentage("3.2"),
+			generalCorporateRate:      types.MustParsePercentage("11.5"),
+			noContributionCredit:      true,
+		},
+		types.Saskatchewan: {
+			brackets: brackets{
+				{0, types.MustParsePercentage("10.5")},
+				{49720 * types.CashDollar, types.MustParsePercentage("12.5")},
+				{142058 * types.CashDollar, types.MustParsePercentage("14.5")},
+			},
+			basicPersonalAmount:       17661 * types.CashDollar,
+			pensionAmount:             1000 * types.CashDollar,
+			eligibleDividendCredit:    types.MustParsePercentage("11"),
+			nonEligibleDividendCredit: types.MustParsePercentage("2.519"),
+			smallBusinessRate:         types.MustParsePercentage("0.5"),
+			generalCorporateRate:      types.MustParsePercentage("12"),
+		},
+		types.Yukon: {
+			brackets: brackets{
+				{0, types.MustParsePercentage("6.4")},
+				{53359 * types.CashDollar, types.MustParsePercentage("9")},
+				{106717 * types.CashDollar, types.MustParsePercentage("10.9")},
+				{165430 * types.CashDollar, types.MustParsePercentage("12.8")},
+				{500000 * types.CashDollar, types.MustParsePercentage("15")},
+			},
+			basicPersonalAmount:       15000 * types.CashDollar,
+			pensionAmount:             2000 * types.CashDollar,
+			eligibleDividendCredit:    types.MustParsePercentage("12.02"),
+			nonEligibleDividendCredit: types.MustParsePercentage("0.67"),
+			smallBusinessRate:         types.MustParsePercentage("0"),
+			generalCorporateRate:      types.MustParsePercentage("12"),
+			followsFederalBPA:         true,
+		},
+		types.TestingProvince: {},
+	}
+
+	provinces2024 = map[types.Province]provincialParameters{
+		types.Alberta: {
+			brackets: brackets{
+				{0, types.MustParsePercentage("10")},
+				{148269 * types.CashDollar, types.MustParsePercentage("12")},
+				{177922 * types.CashDollar, types.MustParsePercentage("13")},
+				{237230 * types.CashDollar, types.MustParsePercentage("14")},
+				{355845 * types.CashDollar, types.MustParsePercentage("15")},
+			},
+			basicPersonalAmount:       21885 * types.CashDollar,
+			pensionAmount:             1719 * types.CashDollar,
+			eligibleDividendCredit:    types.MustParsePercentage("8.12"),
+			nonEligibleDividendCredit: types.MustParsePercentage("2.18"),
+			smallBusinessRate:         types.MustParsePercentage("2"),
+			generalCorporateRate:      types.MustParsePercentage("8"),
+		},
+		types.BritishColumbia: {
+			brackets: brackets{
+				{0, types.MustParsePercentage("5.06")},
+				{47937 * types.CashDollar, types.MustParsePercentage("7.7")},
+				{95875 * types.CashDollar, types.MustParsePercentage("10.5")},
+				{110076 * types.CashDollar, types.MustParsePercentage("12.29")},
+				{133664 * types.CashDollar, types.MustParsePercentage("14.7")},
+				{181232 * types.CashDollar, types.MustParsePercentage("16.8")},
+				{252752 * types.CashDollar, types.MustParsePercentage("20.5")},
+			},
+			basicPersonalAmount:       12580 * types.CashDollar,
+			pensionAmount:             1000 * types.CashDollar,
+			eligibleDividendCredit:    types.MustParsePercentage("12"),
+			nonEligibleDividendCredit: types.MustParsePercentage("1.96"),
+			smallBusinessRate:         types.MustParsePercentage("2"),
+			generalCorporateRate:      types.MustParsePercentage("12"),
+		},
+		types.Manitoba: {
+			brackets: brackets{
+				{0, types.MustParsePercentage("10.8")},
+				{47000 * types.CashDollar, types.MustParsePercentage("12.75")},
+				{100000 * types.CashDollar, types.MustParsePercentage("17.4")},
+			},
+			basicPersonalAmount:       15780 * types.CashDollar,
+			pensionAmount:             1000 * types.CashDollar,
+			eligibleDividendCredit:    types.MustParsePercentage("8"),
+			nonEligibleDividendCredit: types.MustParsePercentage("0.7835"),
+			smallBusinessRate:         types.MustParsePercentage("0"),
+			generalCorporateRate:      types.MustParsePercentage("12"),
+		},
+		types.NewBrunswick: {
+			brackets: brackets{
+				{0, types.MustParsePercentage("9.4")},
+				{49958 * types.CashDollar, types.MustParsePercentage("14")},
+				{99916 * types.CashDollar, types.MustParsePercentage("16")},
+				{185064 * types.CashDollar, types.MustParsePercentage("19.5")},
+			},
+			basicPersonalAmount:       13044 * types.CashDollar,
+			pensionAmount:             1000 * types.CashDollar,
+			eligibleDividendCredit:    types.MustParsePercentage("14"),
+			nonEligibleDividendCredit: types.MustParsePercentage("2.75"),
+			smallBusinessRate:         types.MustParsePercentage("2.5"),
+			generalCorporateRate:      types.MustParsePercentage("14"),
+		},
+		types.NewfoundlandAndLabrador: {
+			brackets: brackets{
+				{0, types.MustParsePercentage("8.7")},
+				{43198 * types.CashDollar, types.MustParsePercentage("14.5")},
+				{86395 * types.CashDollar, types.MustParsePercentage("15.8")},
+				{154244 * types.CashDollar, types.MustParsePercentage("17.8")},
+				{215943 * types.CashDollar, types.MustParsePercentage("19.8")},
+				{275870 * types.CashDollar, types.MustParsePercentage("20.8")},
+				{551739 * types.CashDollar, types.MustParsePercentage("21.3")},
+				{1103478 * types.CashDollar, types.MustParsePercentage("21.8")},
+			},
+			basicPersonalAmount:       10818 * types.CashDollar,
+			pensionAmount:             1000 * types.CashDollar,
+			eligibleDividendCredit:    types.MustParsePercentage("6.3"),
+			nonEligibleDividendCredit: types.MustParsePercentage("3.2"),
+			smallBusinessRate:         types.MustParsePercentage("2.5"),
+			generalCorporateRate:      types.MustParsePercentage("15"),
+		},
+		types.NorthwestTerritories: {
+			brackets: brackets{
+				{0, types.MustParsePercentage("5.9")},
+				{50597 * types.CashDollar, types.MustParsePercentage("8.6")},
+				{101198 * types.CashDollar, types.MustParsePercentage("12.2")},
+				{164525 * types.CashDollar, types.MustParsePercentage("14.05")},
+			},
+			basicPersonalAmount:       17373 * types.CashDollar,
+			pensionAmount:             1000 * types.CashDollar,
+			eligibleDividendCredit:    types.MustParsePercentage("11.5"),
+			nonEligibleDividendCredit: types.MustParsePercentage("6"),
+			smallBusinessRate:         types.MustParsePercentage("2"),
+			generalCorporateRate:      types.MustParsePercentage("11.5"),
+		},
+		types.NovaScotia: {
+			brackets: brackets{
+				{0, types.MustParsePercentage("8.79")},
+				{29590 * types.CashDollar, types.MustParsePercentage("14.95")},
+				{59180 * types.CashDollar, types.MustParsePercentage("16.67")},
+				{93000 * types.CashDollar, types.MustParsePercentage("17.5")},
+				{150000 * types.CashDollar, types.MustParsePercentage("21")},
+			},
+			basicPersonalAmount:       8481 * types.CashDollar,
+			pensionAmount:             1173 * types.CashDollar,
+			eligibleDividendCredit:    types.MustParsePercentage("8.85"),
+			nonEligibleDividendCredit: types.MustParsePercentage("2.99"),
+			smallBusinessRate:         types.MustParsePercentage("2.5"),
+			generalCorporateRate:      types.MustParsePercentage("14"),
+		},
+		types.Nunavut: {
+			brackets: brackets{
+				{0, types.MustParsePercentage("4")},
+				{53268 * types.CashDollar, types.MustParsePercentage("7")},
+				{106537 * types.CashDollar, types.MustParsePercentage("9")},
+				{173205 * types.CashDollar, types.MustParsePercentage("11.5")},
+			},
+			basicPersonalAmount:       18767 * types.CashDollar,
+			pensionAmount:             2000 * types.CashDollar,
+			eligibleDividendCredit:    types.MustParsePercentage("5.51"),
+			nonEligibleDividendCredit: types.MustParsePercentage("2.61"),
+			smallBusinessRate:         types.MustParsePercentage("3"),
+			generalCorporateRate:      types.MustParsePercentage("12"),
+		},
+		types.Ontario: {
+			brackets: brackets{
+				{0, types.MustParsePercentage("5.05")},
+				{51446 * types.CashDollar, types.MustParsePercentage("9.15")},
+				{102894 * types.CashDollar, types.MustParsePercentage("11.16")},
+				{150000 * types.CashDollar, types.MustParsePercentage("12.16")},
+				{220000 * types.CashDollar, types.MustParsePercentage("13.16")},
+			},
+			basicPersonalAmount:       12399 * types.CashDollar,
+			pensionAmount:             1762 * types.CashDollar,
+			eligibleDividendCredit:    types.MustParsePercentage("10"),
+			nonEligibleDividendCredit: types.MustParsePercentage("2.9863"),
+			smallBusinessRate:         types.MustParsePercentage("3.2"),
+			generalCorporateRate:      types.MustParsePercentage("11.5"),
+			surtax: brackets{
+				{0, 0},
+				{5554 * types.CashDollar, types.MustParsePercentage("20")},
+				{7108 * types.CashDollar, types.MustParsePercentage("56")},
+			},
+			healthPremium: ontarioHealthPremium,
+		},
+		types.PrinceEdwardIsland: {
+			brackets: brackets{
+				{0, types.MustParsePercentage("9.65")},
+				{32656 * types.CashDollar, types.MustParsePercentage("13.63")},
+				{64313 * types.CashDollar, types.MustParsePercentage("16.65")},
+				{105000 * types.CashDollar, types.MustParsePercentage("18")},
+				{140000 * types.CashDollar, types.MustParsePercentage("18.75")},
+			},
+			basicPersonalAmount:       13500 * types.CashDollar,
+			pensionAmount:             1000 * types.CashDollar,
+			eligibleDividendCredit:    types.MustParsePercentage("10.5"),
+			nonEligibleDividendCredit: types.MustParsePercentage("1.3"),
+			smallBusinessRate:         types.MustParsePercentage("1"),
+			generalCorporateRate:      types.MustParsePercentage("16"),
+		},
+		types.Quebec: {
+			brackets: brackets{
+				{0, types.MustParsePercentage("14")},
+				{51780 * types.CashDollar, types.MustParsePercentage("19")},
+				{103545 * types.CashDollar, types.MustParsePercentage("24")},
+				{126000 * types.CashDollar, types.MustParsePercentage("25.75")},
+			},
+			basicPersonalAmount:       18056 * types.CashDollar,
+			eligibleDividendCredit:    types.MustParsePercentage("11.7"),
+			nonEligibleDividendCredit: types.MustParsePercentage("3.42"),
+			smallBusinessRate:         types.MustParsePercentage("3.2"),
+			generalCorporateRate:      types.MustParsePercentage("11.5"),
+			noContributionCredit:      true,
+		},
+		types.Saskatchewan: {
+			brackets: brackets{
+				{0, types.MustParsePercentage("10.5")},
+				{52057 * types.CashDollar, types.MustParsePercentage("12.5")},
+				{148734 * types.CashDollar, types.MustParsePercentage("14.5")},
+			},
+			basicPersonalAmount:       18491 * types.CashDollar,
+			pensionAmount:             1000 * types.CashDollar,
+			eligibleDividendCredit:    types.MustParsePercentage("11"),
+			nonEligibleDividendCredit: types.MustParsePercentage("2.938"),
+			smallBusinessRate:         types.MustParsePercentage("1.5"),
+			generalCorporateRate:      types.MustParsePercentage("12"),
+		},
+		types.Yukon: {
+			brackets: brackets{
+				{0, types.MustParsePercentage("6.4")},
+				{55867 * types.CashDollar, types.MustParsePercentage("9")},
+				{111733 * types.CashDollar, types.MustParsePercentage("10.9")},
+				{173205 * types.CashDollar, types.MustParsePercentage("12.8")},
+				{500000 * types.CashDollar, types.MustParsePercentage("15")},
+			},
+			basicPersonalAmount:       15705 * types.CashDollar,
+			pensionAmount:             2000 * types.CashDollar,
+			eligibleDividendCredit:    types.MustParsePercentage("12.02"),
+			nonEligibleDividendCredit: types.MustParsePercentage("0.67"),
+			smallBusinessRate:         types.MustParsePercentage("0"),
+			generalCorporateRate:      types.MustParsePercentage("12"),
+			followsFederalBPA:         true,
+		},
+		types.TestingProvince: {},
+	}
+)
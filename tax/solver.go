@@ -0,0 +1,101 @@
+package tax
+
+import (
+	"errors"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+const (
+	solverMax = 10000000 * types.CashDollar // 10 million $
+)
+
+var (
+	ErrorUnreachableNet = errors.New("the target net pay cannot be reached")
+)
+
+// Returns the rate of income tax and contributions on the next dollar of employment income
+func MarginalRate(province types.Province, year types.Year, income Income) (types.Percentage, error) {
+	base, err := Calculate(province, year, income)
+	if err != nil {
+		return 0, err
+	}
+	income.Employment += types.CashDollar
+	next, err := Calculate(province, year, income)
+	if err != nil {
+		return 0, err
+	}
+	return (base.NetPay() + types.CashDollar - next.NetPay()).FractionOf(types.CashDollar)
+}
+
+// Returns the increase of the net pay from an additional income, such as a consulting offer,
+// and the share of the additional income lost to taxes, contributions and the OAS recovery
+func MarginalValue(province types.Province, year types.Year, income Income, additional Income) (types.Cash, types.Percentage, error) {
+	base, err := Calculate(province, year, income)
+	if err != nil {
+		return 0, 0, err
+	}
+	with, err := Calculate(province, year, income.Add(additional))
+	if err != nil {
+		return 0, 0, err
+	}
+	gain := with.NetPay() - base.NetPay()
+	gross := with.TotalIncome - with.GrossUp - with.ForeignTax - base.TotalIncome + base.GrossUp + base.ForeignTax
+	rate, err := (gross - gain).FractionOf(gross)
+	if err != nil {
+		return 0, 0, err
+	}
+	return gain, rate, nil
+}
+
+// Returns the lowest employment income, to the cent, that results in at least
+// the target net pay given the other income and deductions.
+// Also returns the marginal rate at that employment income.
+func GrossForNet(province types.Province, year types.Year, target types.Cash, income Income) (types.Cash, types.Percentage, error) {
+	netPay := func(employment types.Cash) (types.Cash, error) {
+		income.Employment = employment
+		result, err := Calculate(province, year, income)
+		return result.NetPay(), err
+	}
+	// Find an upper bound
+	low, high := types.Cash(0), 1000*types.CashDollar
+	for {
+		net, err := netPay(high)
+		if err != nil {
+			return 0, 0, err
+		}
+		if net >= target {
+			break
+		}
+		if high >= solverMax {
+			return 0, 0, ErrorUnreachableNet
+		}
+		low, high = high, types.MinCash(2*high, solverMax)
+	}
+	// The net pay grows with the employment income, search for the lowest cent reaching the target
+	net, err := netPay(low)
+	if err != nil {
+		return 0, 0, err
+	}
+	if net >= target {
+		high = low
+	}
+	for high-low > types.CashCent {
+		middle := low + (high-low)/2/types.CashCent*types.CashCent
+		net, err := netPay(middle)
+		if err != nil {
+			return 0, 0, err
+		}
+		if net >= target {
+			high = middle
+		} else {
+			low = middle
+		}
+	}
+	income.Employment = high
+	marginal, err := MarginalRate(province, year, income)
+	if err != nil {
+		return 0, 0, err
+	}
+	return high, marginal, nil
+}
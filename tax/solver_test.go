@@ -0,0 +1,124 @@
+package tax_test
+
+import (
+	"testing"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/tax"
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+func TestMarginalRate(t *testing.T) {
+	testCases := map[struct {
+		province   types.Province
+		employment types.Cash
+	}]types.Percentage{
+		// 15% federal, 5.95% CPP, 1.66% EI, minus the credits and deduction for the contributions
+		{types.TestingProvince, 20000 * types.CashDollar}: 2147 * types.PercentagePoint / 100,
+		// 33% federal, 13.16% provincial with 56% surtax
+		{types.Ontario, 300000 * types.CashDollar}: 5353 * types.PercentagePoint / 100,
+	}
+
+	for testParams, expected := range testCases {
+		rate, err := tax.MarginalRate(testParams.province, 2024, tax.Income{Employment: testParams.employment})
+		if err != nil {
+			t.Fatalf("Unexpected error for %v: %v", testParams, err)
+		}
+		if diff := rate - expected; diff < -types.PercentagePoint/100 || types.PercentagePoint/100 < diff {
+			t.Fatalf("Expected marginal rate %s%% for %v, got %s%%", expected, testParams, rate)
+		}
+	}
+}
+
+func TestGrossForNet(t *testing.T) {
+	testCases := []struct {
+		province types.Province
+		target   types.Cash
+		income   tax.Income
+	}{
+		{types.Ontario, 0, tax.Income{}},
+		{types.Ontario, 50000 * types.CashDollar, tax.Income{}},
+		{types.Quebec, 75000 * types.CashDollar, tax.Income{}},
+		{types.Alberta, 123456789, tax.Income{Deductions: 10000 * types.CashDollar}},
+		{types.BritishColumbia, 60000 * types.CashDollar, tax.Income{Other: 20000 * types.CashDollar}},
+	}
+
+	for _, testCase := range testCases {
+		gross, marginal, err := tax.GrossForNet(testCase.province, 2024, testCase.target, testCase.income)
+		if err != nil {
+			t.Fatalf("Unexpected error for %+v: %v", testCase, err)
+		}
+		if gross%types.CashCent != 0 {
+			t.Fatalf("Expected a whole number of cents for %+v, got %d", testCase, gross)
+		}
+		income := testCase.income
+		income.Employment = gross
+		result, err := tax.Calculate(testCase.province, 2024, income)
+		if err != nil || result.NetPay() < testCase.target {
+			t.Fatalf("Expected %s to reach %s for %+v, got %s, %v", gross, testCase.target, testCase, result.NetPay(), err)
+		}
+		if gross > 0 {
+			income.Employment = gross - types.CashCent
+			result, err = tax.Calculate(testCase.province, 2024, income)
+			if err != nil || result.NetPay() >= testCase.target {
+				t.Fatalf("Expected %s to be the lowest gross for %+v, got %s, %v", gross, testCase, result.NetPay(), err)
+			}
+		}
+		income.Employment = gross
+		if expected, _ := tax.MarginalRate(testCase.province, 2024, income); marginal != expected || marginal <= 0 {
+			t.Fatalf("Expected marginal rate %s for %+v, got %s", expected, testCase, marginal)
+		}
+	}
+	// Known value: 50'000 net in Ontario
+	if gross, _, _ := tax.GrossForNet(types.Ontario, 2024, 50000*types.CashDollar, tax.Income{}); gross < 63000*types.CashDollar || gross > 66000*types.CashDollar {
+		t.Fatalf("Expected about 64'000 gross for 50'000 net in Ontario, got %s", gross)
+	}
+	if _, _, err := tax.GrossForNet(types.Ontario, 2024, 20000000*types.CashDollar, tax.Income{}); err != tax.ErrorUnreachableNet {
+		t.Fatalf("Expected ErrorUnreachableNet, got %v", err)
+	}
+}
+
+func TestMarginalValue(t *testing.T) {
+	consulting := tax.Income{BusinessRevenue: 20000 * types.CashDollar}
+	senior := tax.Income{Age: 70, Pension: 80000 * types.CashDollar, OAS: 8500 * types.CashDollar}
+	gain, rate, err := tax.MarginalValue(types.Ontario, 2024, senior, consulting)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	base, err := tax.Calculate(types.Ontario, 2024, senior)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	with, err := tax.Calculate(types.Ontario, 2024, senior.Add(consulting))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if gain != with.NetPay()-base.NetPay() || with.OASRecovery <= base.OASRecovery {
+		t.Fatalf("Expected a gain of %s with some OAS recovered, got %s", with.NetPay()-base.NetPay(), gain)
+	}
+	// No CPP is due from 70
+	if with.CPP != 0 {
+		t.Fatalf("Expected no CPP at 70, got %s", with.CPP)
+	}
+	// From 65 to 69 both CPP portions are due on the business income, unless opting out
+	working := tax.Income{Age: 67, Pension: 80000 * types.CashDollar}
+	for optOut, expected := range map[bool]types.Cash{false: 196350 * types.CashCent, true: 0} {
+		working.PensionOptOut = optOut
+		result, err := tax.Calculate(types.Ontario, 2024, working.Add(consulting))
+		if err != nil || result.CPP != expected {
+			t.Fatalf("Expected %s of CPP when opting out is %v, got %s, %v", expected, optOut, result.CPP, err)
+		}
+	}
+	// The OAS recovery and age amount reduction add to the rate of a younger person with the same income
+	younger := tax.Income{Age: 60, Pension: 88500 * types.CashDollar}
+	_, youngerRate, err := tax.MarginalValue(types.Ontario, 2024, younger, consulting)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if rate <= youngerRate {
+		t.Fatalf("Expected a rate above %s, got %s", youngerRate, rate)
+	}
+
+	if _, _, err := tax.MarginalValue(types.Ontario, 2020, senior, consulting); err != tax.ErrorUnsupportedYear {
+		t.Fatalf("Expected %v, got %v", tax.ErrorUnsupportedYear, err)
+	}
+}
@@ -0,0 +1,162 @@
+package tax
+
+import (
+	"errors"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+var (
+	ErrorInvalidPayPeriods = errors.New("pay periods must be positive and include the ones paid")
+)
+
+// The pay received from an employer during a year
+type Payroll struct {
+	AnnualSalary types.Cash // Regular salary, annualized from a single pay
+	PayPeriods   int        // Number of pay periods in a year (ex: 26 for bi-weekly pay)
+	PeriodsPaid  int        // Number of pay periods actually paid in the year, 0 if all of them
+	Bonus        types.Cash // Bonuses paid during the year
+	Other        Income     // Income and deductions reported at filing, not subject to withholding
+}
+
+// Withholdings compared with the tax owed at filing
+type Reconciliation struct {
+	SalaryWithholding types.Cash // Income tax withheld on the regular pay
+	BonusWithholding  types.Cash // Income tax withheld on the bonus
+	Liability         types.Cash // Income tax owed for the year
+}
+
+// Returns the total income tax withheld
+func (r Reconciliation) Withheld() types.Cash {
+	return r.SalaryWithholding + r.BonusWithholding
+}
+
+// Returns the expected refund, negative for a balance owing
+func (r Reconciliation) Refund() types.Cash {
+	return r.Withheld() - r.Liability
+}
+
+// Returns the income tax withheld on a bonus with the CRA bonus method:
+// the annual tax from the payroll formulas on the annual salary plus the bonus,
+// minus the annual tax on the annual salary alone.
+// Payroll formulas are published yearly, so only years with known data are supported.
+func BonusWithholding(province types.Province, year types.Year, annualSalary types.Cash, bonus types.Cash) (types.Cash, error) {
+	params, provincial, err := payrollParametersFor(province, year)
+	if err != nil {
+		return 0, err
+	}
+	withBonus, err := payrollTax(params, provincial, province, annualSalary+bonus)
+	if err != nil {
+		return 0, err
+	}
+	withoutBonus, err := payrollTax(params, provincial, province, annualSalary)
+	if err != nil {
+		return 0, err
+	}
+	return withBonus - withoutBonus, nil
+}
+
+// Returns the parameters for the payroll formulas of a given year,
+// unlike Calculate later years are not supported
+func payrollParametersFor(province types.Province, year types.Year) (parameters, provincialParameters, error) {
+	if year > lastYear {
+		return parameters{}, provincialParameters{}, ErrorUnsupportedYear
+	}
+	return provincialParametersFor(province, year)
+}
+
+// Returns the annual income tax withheld on the annual employment income
+// with the CRA payroll formulas (T4127, option 1) and a default TD1 claim.
+// The full basic personal amounts are claimed whatever the income, no other credits
+// are known to the employer, and Quebec provincial withholding is approximated
+// with the same formula rather than Revenu Québec's.
+func payrollTax(params parameters, provincial provincialParameters, province types.Province, annual types.Cash) (types.Cash, error) {
+	contributions, err := params.payroll.employee(province, annual)
+	if err != nil {
+		return 0, err
+	}
+	// A: annual taxable income, after the deductible contributions (F5A)
+	taxable := types.MaxCash(0, annual-contributions.deductible())
+	// T3: basic federal tax, K1 for the TD1 claim, K2 for the contributions and K4 for the Canada employment amount
+	credits := params.federal.basicPersonalAmount + contributions.creditable() +
+		types.MinCash(params.federal.canadaEmploymentAmount, types.MaxCash(0, annual))
+	federal, err := netTax(params.federal.brackets, taxable, credits)
+	if err != nil {
+		return 0, err
+	}
+	if province == types.Quebec {
+		abatement, err := federal.Percentage(params.federal.quebecAbatement)
+		if err != nil {
+			return 0, err
+		}
+		federal -= abatement
+	}
+	// T4: basic provincial tax, K1P for the TD1 claim and K2P for the contributions
+	credits = provincial.basicPersonalAmount
+	if provincial.followsFederalBPA {
+		credits = params.federal.basicPersonalAmount
+	}
+	if !provincial.noContributionCredit {
+		credits += contributions.creditable()
+	}
+	basic, err := netTax(provincial.brackets, taxable, credits)
+	if err != nil {
+		return 0, err
+	}
+	// T2: provincial tax with the surtax (V1) and the health premium (V2)
+	surtax, err := provincial.surtax.tax(basic)
+	if err != nil {
+		return 0, err
+	}
+	premium, err := healthPremium(provincial.healthPremium, taxable)
+	if err != nil {
+		return 0, err
+	}
+	return federal + basic + surtax + premium, nil
+}
+
+// Compares the income tax withheld on the pay, including bonuses,
+// with the income tax owed at filing.
+// Only years with known payroll formulas are supported.
+func Reconcile(province types.Province, year types.Year, payroll Payroll) (Reconciliation, error) {
+	periodsPaid := payroll.PeriodsPaid
+	if periodsPaid == 0 {
+		periodsPaid = payroll.PayPeriods
+	}
+	if payroll.PayPeriods <= 0 || periodsPaid < 0 || periodsPaid > payroll.PayPeriods {
+		return Reconciliation{}, ErrorInvalidPayPeriods
+	}
+	// Withholding on the regular pay, assuming the salary is earned all year
+	params, provincial, err := payrollParametersFor(province, year)
+	if err != nil {
+		return Reconciliation{}, err
+	}
+	annual, err := payrollTax(params, provincial, province, payroll.AnnualSalary)
+	if err != nil {
+		return Reconciliation{}, err
+	}
+	salaryWithholding, err := annual.MulDiv(types.Cash(periodsPaid), types.Cash(payroll.PayPeriods))
+	if err != nil {
+		return Reconciliation{}, err
+	}
+	bonusWithholding, err := BonusWithholding(province, year, payroll.AnnualSalary, payroll.Bonus)
+	if err != nil {
+		return Reconciliation{}, err
+	}
+	// Liability at filing
+	salary, err := payroll.AnnualSalary.MulDiv(types.Cash(periodsPaid), types.Cash(payroll.PayPeriods))
+	if err != nil {
+		return Reconciliation{}, err
+	}
+	income := payroll.Other
+	income.Employment += salary + payroll.Bonus
+	actual, err := Calculate(province, year, income)
+	if err != nil {
+		return Reconciliation{}, err
+	}
+	return Reconciliation{
+		SalaryWithholding: salaryWithholding,
+		BonusWithholding:  bonusWithholding,
+		Liability:         actual.IncomeTax(),
+	}, nil
+}
@@ -0,0 +1,73 @@
+package tax_test
+
+import (
+	"testing"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/tax"
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+func TestBonusWithholding(t *testing.T) {
+	withholding, err := tax.BonusWithholding(types.Ontario, 2024, 100000*types.CashDollar, 10000*types.CashDollar)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// The bonus is mostly taxed at 20.5% federal plus 9.15% or 11.16% provincial, with the 20% surtax
+	if withholding != 33191318 {
+		t.Fatalf("Expected 3'319.13 withheld on the bonus, got %d (%s)", withholding, withholding)
+	}
+	if withholding, err := tax.BonusWithholding(types.Ontario, 2024, 100000*types.CashDollar, 0); withholding != 0 || err != nil {
+		t.Fatalf("Expected nothing withheld without a bonus, got %s, %v", withholding, err)
+	}
+	if _, err := tax.BonusWithholding(types.Ontario, 2025, 100000*types.CashDollar, 10000*types.CashDollar); err != tax.ErrorUnsupportedYear {
+		t.Fatalf("Expected ErrorUnsupportedYear without payroll formulas, got %v", err)
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	testCases := map[string]struct {
+		payroll tax.Payroll
+		refund  func(types.Cash) bool
+	}{
+		"full year": {
+			tax.Payroll{AnnualSalary: 100000 * types.CashDollar, PayPeriods: 26, Bonus: 10000 * types.CashDollar},
+			func(refund types.Cash) bool { return -types.CashCent < refund && refund < types.CashCent },
+		},
+		"hired mid-year": {
+			tax.Payroll{AnnualSalary: 100000 * types.CashDollar, PayPeriods: 26, PeriodsPaid: 13, Bonus: 10000 * types.CashDollar},
+			func(refund types.Cash) bool { return refund > 1000*types.CashDollar },
+		},
+		"rrsp contribution": {
+			tax.Payroll{AnnualSalary: 100000 * types.CashDollar, PayPeriods: 26, Bonus: 10000 * types.CashDollar, Other: tax.Income{Deductions: 10000 * types.CashDollar}},
+			func(refund types.Cash) bool { return refund > 3000*types.CashDollar },
+		},
+		"high earner": {
+			// The default TD1 claims the full federal basic personal amount, which is reduced at filing
+			tax.Payroll{AnnualSalary: 250000 * types.CashDollar, PayPeriods: 26, Bonus: 50000 * types.CashDollar},
+			func(refund types.Cash) bool { return refund == -2323500 },
+		},
+		"side income": {
+			tax.Payroll{AnnualSalary: 100000 * types.CashDollar, PayPeriods: 12, Other: tax.Income{Other: 5000 * types.CashDollar}},
+			func(refund types.Cash) bool { return refund < -1500*types.CashDollar },
+		},
+	}
+
+	for name, testCase := range testCases {
+		reconciliation, err := tax.Reconcile(types.Ontario, 2024, testCase.payroll)
+		if err != nil {
+			t.Fatalf("Unexpected error for %q: %v", name, err)
+		}
+		if reconciliation.Withheld() != reconciliation.SalaryWithholding+reconciliation.BonusWithholding {
+			t.Fatalf("Unexpected withholding for %q: %+v", name, reconciliation)
+		}
+		if refund := reconciliation.Refund(); !testCase.refund(refund) {
+			t.Fatalf("Unexpected refund %s for %q: %+v", refund, name, reconciliation)
+		}
+	}
+
+	for _, payroll := range []tax.Payroll{{PayPeriods: 0}, {PayPeriods: 12, PeriodsPaid: 13}, {PayPeriods: 12, PeriodsPaid: -1}} {
+		if _, err := tax.Reconcile(types.Ontario, 2024, payroll); err != tax.ErrorInvalidPayPeriods {
+			t.Fatalf("Expected ErrorInvalidPayPeriods for %+v, got %v", payroll, err)
+		}
+	}
+}
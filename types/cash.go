@@ -101,3 +101,32 @@ func (c Cash) FractionOf(other Cash) (Percentage, error) {
 	}
 	return Percentage(c) * percentage100 / Percentage(other), nil
 }
+
+// Returns this Cash times numerator over denominator, detecting potential overflows
+// If denominator == 0 will return 0
+func (c Cash) MulDiv(numerator, denominator Cash) (Cash, error) {
+	if c == 0 || numerator == 0 || denominator == 0 {
+		return 0, nil
+	}
+	// Detect potential overflows
+	if math.MaxInt64/i64Abs(int64(numerator)) < i64Abs(int64(c)) {
+		return 0, ErrorOverflow
+	}
+	return c * numerator / denominator, nil
+}
+
+// Returns the lowest between a and b
+func MinCash(a, b Cash) Cash {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// Returns the highest between a and b
+func MaxCash(a, b Cash) Cash {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -182,6 +182,35 @@ func TestCashFractionOf(t *testing.T) {
 	}
 }
 
+func TestCashMulDiv(t *testing.T) {
+	testCases := map[[3]types.Cash]struct {
+		cash     types.Cash
+		hasError bool
+	}{
+		{0, 1, 1}:                         {0, false},
+		{types.CashDollar, 1, 0}:          {0, false},
+		{types.CashDollar, 3, 4}:          {7500, false},
+		{-types.CashDollar, 1, 3}:         {-3333, false},
+		{types.Cash(math.MaxInt64), 2, 1}: {0, true},
+	}
+
+	for testParams, testExpected := range testCases {
+		result, err := testParams[0].MulDiv(testParams[1], testParams[2])
+		if (err != nil) != testExpected.hasError || result != testExpected.cash {
+			t.Fatalf("Expected %d (error: %v) from %v, instead got %d, %v", testExpected.cash, testExpected.hasError, testParams, result, err)
+		}
+	}
+}
+
+func TestMinMaxCash(t *testing.T) {
+	if min := types.MinCash(-types.CashDollar, types.CashCent); min != -types.CashDollar {
+		t.Fatalf("Expected the lowest to be -1$, got %s", min)
+	}
+	if max := types.MaxCash(-types.CashDollar, types.CashCent); max != types.CashCent {
+		t.Fatalf("Expected the highest to be 0.01$, got %s", max)
+	}
+}
+
 func BenchmarkNewCash(b *testing.B) {
 	b.Run("zero", func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
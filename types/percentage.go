@@ -22,6 +22,16 @@ func ParsePercentage(percentageStr string, allowNegative bool) (Percentage, erro
 	return Percentage(i), err
 }
 
+// Parse a percentage from string, panics on failure.
+// Only meant to be used to define constant rates.
+func MustParsePercentage(percentageStr string) Percentage {
+	p, err := ParsePercentage(percentageStr, false)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
 // Returns the string value of percentage formatted as:
 // "12.3" / "12.34" or "-12" if no fraction present
 func (p Percentage) String() string {
@@ -72,6 +72,18 @@ func TestPercentage(t *testing.T) {
 	}
 }
 
+func TestMustParsePercentage(t *testing.T) {
+	if p := types.MustParsePercentage("12.5%"); p != 12500000 {
+		t.Fatalf("Expected 12.5%%, got %s%%", p)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Expected a panic for an invalid percentage")
+		}
+	}()
+	types.MustParsePercentage("99999999999999999999")
+}
+
 func BenchmarkNewPercentage(b *testing.B) {
 	b.Run("zero", func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
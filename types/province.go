@@ -4,22 +4,39 @@ import (
 	"strings"
 )
 
+const (
+	Alberta                 Province = 0
+	BritishColumbia         Province = 1
+	Manitoba                Province = 2
+	NewBrunswick            Province = 3
+	NewfoundlandAndLabrador Province = 4
+	NorthwestTerritories    Province = 5
+	NovaScotia              Province = 6
+	Nunavut                 Province = 7
+	Ontario                 Province = 8
+	PrinceEdwardIsland      Province = 9
+	Quebec                  Province = 10
+	Saskatchewan            Province = 11
+	Yukon                   Province = 12
+	TestingProvince         Province = 255
+)
+
 var (
 	codeToProvince = map[string]Province{
-		"ab": 0,   // Alberta
-		"bc": 1,   // British Columbia
-		"mb": 2,   // Manitoba
-		"nb": 3,   // New Brunswick
-		"nl": 4,   // Newfoundland and Labrador
-		"nt": 5,   // Northwest Territories
-		"ns": 6,   // Nova Scotia
-		"nu": 7,   // Nunavut
-		"on": 8,   // Ontario
-		"pe": 9,   // Prince Edward Island
-		"qc": 10,  // Quebec
-		"sk": 11,  // Saskatchewan
-		"yt": 12,  // Yukon
-		"xx": 255, // Testing
+		"ab": Alberta,
+		"bc": BritishColumbia,
+		"mb": Manitoba,
+		"nb": NewBrunswick,
+		"nl": NewfoundlandAndLabrador,
+		"nt": NorthwestTerritories,
+		"ns": NovaScotia,
+		"nu": Nunavut,
+		"on": Ontario,
+		"pe": PrinceEdwardIsland,
+		"qc": Quebec,
+		"sk": Saskatchewan,
+		"yt": Yukon,
+		"xx": TestingProvince,
 	}
 )
 
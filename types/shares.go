@@ -0,0 +1,83 @@
+package types
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	ShareUnit = Shares(10000)
+)
+
+// The Shares type represents a quantity of shares, fractional shares are allowed.
+// It's a int64 representing 1/10'000 of a share.
+type Shares int64
+
+// Parse a quantity of shares from string, and up to 4 decimals.
+// Valid strings examples are:
+// "400", "12.5", "1'000 shares", "0.0001"
+func ParseShares(sharesStr string) (Shares, error) {
+	i, err := parseNumber(sharesStr, false, 4)
+	return Shares(i), err
+}
+
+// Returns the string value of shares formatted as:
+// "-1'234.5" or "1'234" if no fraction present
+func (s Shares) String() string {
+	isNegative := s < 0
+	if isNegative {
+		s *= -1
+	}
+	// Format fraction, trimming the trailing zeros
+	fraction := strings.TrimRight(strconv.FormatInt(int64(s%ShareUnit+ShareUnit), 10)[1:], "0")
+	if fraction != "" {
+		fraction = "." + fraction
+	}
+	// Format units
+	unitsRaw := strconv.FormatInt(int64(s/ShareUnit), 10)
+	lenUnitsRaw := len(unitsRaw)
+	unitsBuilder := strings.Builder{}
+	unitsBuilder.Grow(lenUnitsRaw + lenUnitsRaw/3 + 1)
+	if isNegative {
+		unitsBuilder.WriteByte('-')
+	}
+	for i := 0; i < lenUnitsRaw; i++ {
+		unitsBuilder.WriteByte(unitsRaw[i])
+		if (lenUnitsRaw-i)%3 == 1 && i != lenUnitsRaw-1 {
+			unitsBuilder.WriteByte('\'')
+		}
+	}
+	return unitsBuilder.String() + fraction
+}
+
+// String, but for JSON marshalling
+func (s Shares) MarshalText() (text []byte, err error) {
+	return []byte(s.String()), nil
+}
+
+// Returns the value of this quantity of shares given the price of one share
+func (s Shares) Value(price Cash) (Cash, error) {
+	// Quick special case for 0
+	if s == 0 || price == 0 {
+		return 0, nil
+	}
+	// Detect potential overflows
+	if math.MaxInt64/i64Abs(int64(price)) < i64Abs(int64(s)) {
+		return 0, ErrorOverflow
+	}
+	return Cash(int64(s) * int64(price) / int64(ShareUnit)), nil
+}
+
+// Returns a given percentage of this quantity of shares
+func (s Shares) Percentage(p Percentage) (Shares, error) {
+	// Quick special case for 0
+	if s == 0 || p == 0 {
+		return 0, nil
+	}
+	// Detect potential overflows
+	if math.MaxInt64/i64Abs(int64(p)) < i64Abs(int64(s)) {
+		return 0, ErrorOverflow
+	}
+	return Shares(int64(s) * int64(p) / int64(percentage100)), nil
+}
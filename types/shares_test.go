@@ -0,0 +1,94 @@
+package types_test
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/stefanovazzocell/SalaryAdvisor/types"
+)
+
+func TestShares(t *testing.T) {
+	testCases := map[string]struct {
+		value     types.Shares // The parsed value we expect
+		strFormat string       // String Value
+	}{
+		"":            {0, "0"},
+		"0":           {0, "0"},
+		"400":         {400 * types.ShareUnit, "400"},
+		"12.5":        {125000, "12.5"},
+		"0.0001":      {1, "0.0001"},
+		"1'000.25":    {10002500, "1'000.25"},
+		"-3 shares":   {3 * types.ShareUnit, "3"},
+		"1.123456789": {11234, "1.1234"},
+	}
+
+	for testStr, testExpected := range testCases {
+		shares, err := types.ParseShares(testStr)
+		if err != nil {
+			t.Fatalf("Unexpected error for %q: %v", testStr, err)
+		}
+		if shares != testExpected.value {
+			t.Fatalf("Expected %d for %q, instead got %d", testExpected.value, testStr, shares)
+		}
+		if str := shares.String(); str != testExpected.strFormat {
+			t.Fatalf("Expected %q from String() of %q, instead got %q", testExpected.strFormat, testStr, str)
+		}
+		if jsonStr, err := shares.MarshalText(); !bytes.Equal(jsonStr, []byte(testExpected.strFormat)) || err != nil {
+			t.Fatalf("Expected %q from MarshalText() of %q, instead got %q, %v", testExpected.strFormat, testStr, jsonStr, err)
+		}
+	}
+	if str := types.Shares(-12500).String(); str != "-1.25" {
+		t.Fatalf("Expected \"-1.25\" for negative shares, instead got %q", str)
+	}
+}
+
+func TestSharesValue(t *testing.T) {
+	testCases := map[struct {
+		shares types.Shares
+		price  types.Cash
+	}]struct {
+		value    types.Cash
+		hasError bool
+	}{
+		{0, types.CashDollar}:                               {0, false},
+		{types.ShareUnit, 0}:                                {0, false},
+		{400 * types.ShareUnit, 120 * types.CashDollar}:     {48000 * types.CashDollar, false},
+		{types.ShareUnit / 2, 3 * types.CashDollar}:         {150 * types.CashCent, false},
+		{-types.ShareUnit, types.CashDollar}:                {-types.CashDollar, false},
+		{types.Shares(math.MaxInt64), 2 * types.CashDollar}: {0, true},
+	}
+
+	for testParams, testExpected := range testCases {
+		value, err := testParams.shares.Value(testParams.price)
+		if err != nil && !testExpected.hasError {
+			t.Fatalf("Unexpected error for %v: %v", testParams, err)
+		} else if err == nil && testExpected.hasError {
+			t.Fatalf("Expected an error for %v, instead got %d", testParams, value)
+		} else if value != testExpected.value {
+			t.Fatalf("Expected %d for %v, instead got %d", testExpected.value, testParams, value)
+		}
+	}
+}
+
+func TestSharesPercentage(t *testing.T) {
+	testCases := map[struct {
+		shares     types.Shares
+		percentage types.Percentage
+	}]struct {
+		shares   types.Shares
+		hasError bool
+	}{
+		{0, 50 * types.PercentagePoint}:                            {0, false},
+		{400 * types.ShareUnit, 25 * types.PercentagePoint}:        {100 * types.ShareUnit, false},
+		{types.ShareUnit, 12500000}:                                {1250, false},
+		{types.Shares(math.MaxInt64), 100 * types.PercentagePoint}: {0, true},
+	}
+
+	for testParams, testExpected := range testCases {
+		shares, err := testParams.shares.Percentage(testParams.percentage)
+		if (err != nil) != testExpected.hasError || shares != testExpected.shares {
+			t.Fatalf("Expected %d (error: %v) for %v, instead got %d, %v", testExpected.shares, testExpected.hasError, testParams, shares, err)
+		}
+	}
+}
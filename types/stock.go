@@ -2,51 +2,113 @@ package types
 
 import (
 	"math"
+	"strings"
 )
 
 // Stock represents a stock value
 type Stock struct {
-	value           Cash // The cash value in the stock currency
-	conversionPrice Cash // conversion Cash from stock currency to CAD (1$ | 0$ == stock in CAD)
+	value           Cash   // The cash value in the stock currency
+	quantity        Shares // The number of shares (0 == unknown)
+	conversionPrice Cash   // conversion Cash from stock currency to CAD (1$ | 0$ == stock in CAD)
 }
 
 // Create a new stock given its value and conversion price
-// Ex: ("10USD", "1.23CAD/USD")
+// The value can either be a total or a quantity at a per-share price
+// Ex: ("10USD", "1.23CAD/USD"), ("400 @ 120USD", "1.36")
 // Note: conversion prices 0 and 1 mean the value is in CAD
 // i.e. ("10CAD", "0") == ("10CAD", "1")
 func ParseStock(valueStr string, conversionStr string) (Stock, error) {
-	value, err := ParseCash(valueStr, false)
+	conversion, err := ParseCash(conversionStr, false)
 	if err != nil {
 		return Stock{}, err
 	}
-	conversion, err := ParseCash(conversionStr, false)
+	// Quantity at a per-share price
+	if quantityStr, priceStr, found := strings.Cut(valueStr, "@"); found {
+		quantity, err := ParseShares(quantityStr)
+		if err != nil {
+			return Stock{}, err
+		}
+		price, err := ParseCash(priceStr, false)
+		if err != nil {
+			return Stock{}, err
+		}
+		return NewStockShares(quantity, price, conversion)
+	}
+	// Total value
+	value, err := ParseCash(valueStr, false)
 	if err != nil {
 		return Stock{}, err
 	}
-	if conversion == 0 {
+	return NewStock(value, conversion), nil
+}
+
+// Create a new stock given its value and conversion price
+// Note: conversion prices 0 and 1 mean the value is in CAD
+func NewStock(value Cash, conversion Cash) Stock {
+	if conversion <= 0 {
 		conversion = CashDollar
 	}
 	return Stock{
 		value:           value,
 		conversionPrice: conversion,
-	}, nil
+	}
+}
+
+// Create a new stock given a quantity of shares,
+// the price of one share and the conversion price
+// Note: conversion prices 0 and 1 mean the value is in CAD
+func NewStockShares(quantity Shares, price Cash, conversion Cash) (Stock, error) {
+	value, err := quantity.Value(price)
+	if err != nil {
+		return Stock{}, err
+	}
+	if cashMax < int64(value) {
+		return Stock{}, ErrorCashMaxed
+	}
+	stock := NewStock(value, conversion)
+	stock.quantity = quantity
+	return stock, nil
 }
 
 // Multiply the stock for a given percentage
+// If known, the quantity of shares is scaled as well
 func (s Stock) Percentage(p Percentage) (Stock, error) {
 	newValue, err := s.value.Percentage(p)
 	if err != nil {
 		return Stock{}, err
 	}
+	newQuantity, err := s.quantity.Percentage(p)
+	if err != nil {
+		return Stock{}, err
+	}
 	s.value = newValue
+	s.quantity = newQuantity
 	return s, nil
 }
 
+// Returns the number of shares, 0 if unknown
+func (s Stock) Quantity() Shares {
+	return s.quantity
+}
+
+// Returns the price of one share in the original currency,
+// 0 if the quantity is unknown
+func (s Stock) Price() Cash {
+	if s.quantity == 0 {
+		return 0
+	}
+	// Detect potential overflows
+	if math.MaxInt64/int64(ShareUnit) < i64Abs(int64(s.value)) {
+		return s.value / Cash(s.quantity) * Cash(ShareUnit)
+	}
+	return s.value * Cash(ShareUnit) / Cash(s.quantity)
+}
+
 // Returns Cash in CAD, Cash in the original value,
 // a bool indicating if original currency is CAD
 func (s Stock) Value() (cad Cash, orig Cash, isCAD bool, err error) {
 	// If CAD, return value
-	isCAD = s.conversionPrice == CashDollar
+	isCAD = s.conversionPrice == CashDollar || s.conversionPrice == 0
 	if isCAD {
 		return s.value, s.value, true, nil
 	}
@@ -57,6 +119,14 @@ func (s Stock) Value() (cad Cash, orig Cash, isCAD bool, err error) {
 	return s.value * s.conversionPrice / CashDollar, s.value, false, nil
 }
 
+// Returns the conversion price from the stock currency to CAD
+func (s Stock) Conversion() Cash {
+	if s.conversionPrice == 0 {
+		return CashDollar
+	}
+	return s.conversionPrice
+}
+
 func (s Stock) MarshalText() (text []byte, err error) {
 	cad, _, _, err := s.Value()
 	if err != nil {
@@ -118,3 +118,90 @@ func TestStockPercentageMarshall(t *testing.T) {
 		t.Fatalf("Did not overflow Stock().Percentage, instead got %v", stock)
 	}
 }
+
+func TestNewStock(t *testing.T) {
+	testCases := map[struct {
+		value      types.Cash
+		conversion types.Cash
+	}]struct {
+		cad        types.Cash
+		conversion types.Cash
+		isCad      bool
+	}{
+		{0, 0}:                               {0, types.CashDollar, true},
+		{types.CashDollar, -1}:               {types.CashDollar, types.CashDollar, true},
+		{types.CashDollar, types.CashDollar}: {types.CashDollar, types.CashDollar, true},
+		{100 * types.CashDollar, types.CashDollar / 2}: {50 * types.CashDollar, types.CashDollar / 2, false},
+	}
+
+	for testParams, testExpected := range testCases {
+		stock := types.NewStock(testParams.value, testParams.conversion)
+		cad, orig, isCad, err := stock.Value()
+		if err != nil {
+			t.Fatalf("Unexpected error for %v: %v", testParams, err)
+		}
+		if cad != testExpected.cad || orig != testParams.value || isCad != testExpected.isCad {
+			t.Fatalf("Expected %d (%v) for %v, instead got %d, %d (%v)",
+				testExpected.cad, testExpected.isCad, testParams, cad, orig, isCad)
+		}
+		if conversion := stock.Conversion(); conversion != testExpected.conversion {
+			t.Fatalf("Expected conversion %d for %v, instead got %d", testExpected.conversion, testParams, conversion)
+		}
+	}
+	// The zero value is in CAD
+	if cad, _, isCad, err := (types.Stock{}).Value(); cad != 0 || !isCad || err != nil {
+		t.Fatalf("Expected zero value stock to be 0 CAD, got %d, %v, %v", cad, isCad, err)
+	}
+}
+
+func TestStockShares(t *testing.T) {
+	testCases := map[struct {
+		value      string
+		conversion string
+	}]struct {
+		hasError bool // Does it return an error? If so, stop here
+		quantity types.Shares
+		price    types.Cash
+		cadValue types.Cash
+	}{
+		{"400 @ 120USD", "1.36"}:    {false, 400 * types.ShareUnit, 120 * types.CashDollar, 65280 * types.CashDollar},
+		{"12.5@10", ""}:             {false, 125000, 10 * types.CashDollar, 125 * types.CashDollar},
+		{"0 @ 10", ""}:              {false, 0, 0, 0},
+		{"1'000'000 @ 2'000", ""}:   {true, 0, 0, 0},
+		{"10 @ 10'000'000'000", ""}: {true, 0, 0, 0},
+		{"1'000$", "1.36"}:          {false, 0, 0, 1360 * types.CashDollar},
+	}
+
+	for testParam, testExpect := range testCases {
+		s, err := types.ParseStock(testParam.value, testParam.conversion)
+		if err != nil && !testExpect.hasError {
+			t.Fatalf("Unexpected error for %v: %v", testParam, err)
+		} else if err == nil && testExpect.hasError {
+			t.Fatalf("Expected error for %v, but %v returned", testParam, s)
+		} else if err != nil {
+			continue
+		}
+		if quantity := s.Quantity(); quantity != testExpect.quantity {
+			t.Fatalf("Expected quantity %s for %v, instead got %s", testExpect.quantity, testParam, quantity)
+		}
+		if price := s.Price(); price != testExpect.price {
+			t.Fatalf("Expected price %s for %v, instead got %s", testExpect.price, testParam, price)
+		}
+		if cad, _, _, err := s.Value(); cad != testExpect.cadValue || err != nil {
+			t.Fatalf("Expected value %s for %v, instead got %s, %v", testExpect.cadValue, testParam, cad, err)
+		}
+	}
+
+	// Scaling a stock scales its quantity, but not its price
+	stock, err := types.NewStockShares(400*types.ShareUnit, 120*types.CashDollar, 13600)
+	if err != nil {
+		t.Fatalf("Failed to setup stock: %v", err)
+	}
+	stock, err = stock.Percentage(25 * types.PercentagePoint)
+	if err != nil {
+		t.Fatalf("Failed to grab percentage stock: %v", err)
+	}
+	if stock.Quantity() != 100*types.ShareUnit || stock.Price() != 120*types.CashDollar {
+		t.Fatalf("Expected 100 shares at 120, instead got %s at %s", stock.Quantity(), stock.Price())
+	}
+}